@@ -0,0 +1,37 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// ArrayLiteral is any array value
+// [1, 2 * 2, foo]
+type ArrayLiteral struct {
+	Token    token.Token // the `[` token
+	Elements []Expression
+}
+
+func (al *ArrayLiteral) expressionNode() {}
+
+// TokenLiteral returns the first token of the array - `[`
+func (al *ArrayLiteral) TokenLiteral() string {
+	return al.Token.Literal
+}
+
+func (al *ArrayLiteral) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, el := range al.Elements {
+		elements = append(elements, el.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"bytes"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// AssignExpression updates the value of an existing variable,
+// or of an element of an array or a hash
+// x = 5
+// x += 1
+// arr[0] = 5
+type AssignExpression struct {
+	Token    token.Token // the assignment operator token, e.g. `=` or `+=`
+	Target   Expression  // *Identifier or *IndexExpression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode() {}
+
+// TokenLiteral returns the assignment operator
+func (ae *AssignExpression) TokenLiteral() string {
+	return ae.Token.Literal
+}
+
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ae.Target.String())
+	out.WriteString(" " + ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
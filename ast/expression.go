@@ -9,6 +9,7 @@ import (
 // PrefixExpression is any expression for prefix expressions
 // -5
 // --5
+// ~5
 // !false
 type PrefixExpression struct {
 	Token    token.Token // The prefix token, e.g. !
@@ -61,3 +62,62 @@ func (ie *InfixExpression) String() string {
 
 	return out.String()
 }
+
+// PostfixExpression is any expression for postfix expressions
+// x++
+// x--
+// f()?
+type PostfixExpression struct {
+	Token    token.Token // The postfix token, e.g. ++
+	Operator string
+	Left     Expression
+}
+
+func (pe *PostfixExpression) expressionNode() {}
+
+// TokenLiteral returns the PostfixExpression token literal
+func (pe *PostfixExpression) TokenLiteral() string {
+	return pe.Token.Literal
+}
+
+func (pe *PostfixExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(pe.Left.String())
+	out.WriteString(pe.Operator)
+	out.WriteString(")")
+
+	return out.String()
+}
+
+// ConditionalExpression is any ternary conditional expression,
+// only one of the branches is ever evaluated
+// x > 0 ? x : -x
+type ConditionalExpression struct {
+	Token       token.Token // the `?` token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (ce *ConditionalExpression) expressionNode() {}
+
+// TokenLiteral returns the ConditionalExpression token literal - `?`
+func (ce *ConditionalExpression) TokenLiteral() string {
+	return ce.Token.Literal
+}
+
+func (ce *ConditionalExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ce.Condition.String())
+	out.WriteString(" ? ")
+	out.WriteString(ce.Consequence.String())
+	out.WriteString(" : ")
+	out.WriteString(ce.Alternative.String())
+	out.WriteString(")")
+
+	return out.String()
+}
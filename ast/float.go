@@ -0,0 +1,22 @@
+package ast
+
+import "github.com/fr3fou/monkey/token"
+
+// FloatLiteral is any floating point value
+// 3.14
+// 1e9
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+
+// TokenLiteral returns the float token literal
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
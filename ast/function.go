@@ -13,6 +13,10 @@ type FunctionLiteral struct {
 	Token      token.Token
 	Parameters []*Identifier
 	Body       *BlockStatement
+
+	// Name is the name the function is declared or bound with (if any),
+	// it's used in stack traces
+	Name string
 }
 
 func (fl *FunctionLiteral) expressionNode() {}
@@ -39,6 +43,40 @@ func (fl *FunctionLiteral) String() string {
 	return out.String()
 }
 
+// FunctionStatement is any named function declaration,
+// it's hoisted, so the function can be called before it's declared
+// fun add(x, y) { x + y }
+type FunctionStatement struct {
+	Token    token.Token // the `fun` token
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (fs *FunctionStatement) statementNode() {}
+
+// TokenLiteral returns the first token of the declaration - `fun`
+func (fs *FunctionStatement) TokenLiteral() string {
+	return fs.Token.Literal
+}
+
+func (fs *FunctionStatement) String() string {
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range fs.Function.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString(fs.TokenLiteral() + " ")
+	out.WriteString(fs.Name.String())
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(")")
+	out.WriteString(fs.Function.Body.String())
+
+	return out.String()
+}
+
 // CallExpression is any invokement of functions
 type CallExpression struct {
 	Token     token.Token // the `(` token
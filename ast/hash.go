@@ -0,0 +1,44 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// HashLiteral is any hash map value,
+// the pairs are kept in the order they were written in
+// {"one": 1, two: 2, 3: true}
+type HashLiteral struct {
+	Token token.Token // the `{` token
+	Pairs []HashPair
+}
+
+// HashPair is a single `key: value` pair inside of a HashLiteral
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+func (hl *HashLiteral) expressionNode() {}
+
+// TokenLiteral returns the first token of the hash - `{`
+func (hl *HashLiteral) TokenLiteral() string {
+	return hl.Token.Literal
+}
+
+func (hl *HashLiteral) String() string {
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.Key.String()+": "+pair.Value.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
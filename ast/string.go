@@ -0,0 +1,25 @@
+package ast
+
+import (
+	"strconv"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// StringLiteral is any string value
+// "foo bar"
+type StringLiteral struct {
+	Token token.Token
+	Value string
+}
+
+func (sl *StringLiteral) expressionNode() {}
+
+// TokenLiteral returns the string token literal
+func (sl *StringLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+
+func (sl *StringLiteral) String() string {
+	return strconv.Quote(sl.Value)
+}
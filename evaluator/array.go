@@ -0,0 +1,73 @@
+package evaluator
+
+import "github.com/fr3fou/monkey/object"
+
+// evalIndexExpression returns the element of left at the given index
+func evalIndexExpression(left, index object.Object) object.Object {
+	switch {
+	case left.Type() == object.ARRAY_OBJ && isInteger(index):
+		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.ARRAY_OBJ:
+		return newError("array index must be an integer, got %s", index.Type())
+	case left.Type() == object.HASH_OBJ:
+		return evalHashIndexExpression(left, index)
+	default:
+		return newError("index operator not supported: %s", left.Type())
+	}
+}
+
+// evalArrayIndexExpression returns the element at the given index,
+// negative indices count backwards from the end of the array
+// [1, 2, 3][0] // 1
+// [1, 2, 3][-1] // 3
+func evalArrayIndexExpression(array, index object.Object) object.Object {
+	elements := array.(*object.Array).Elements
+
+	i, ok := arrayIndex(index, len(elements))
+	if !ok {
+		return newError("index out of range: %s (length %d)", index.Inspect(), len(elements))
+	}
+
+	return elements[i]
+}
+
+// arrayIndex resolves (possibly negative) indices into
+// an array of the given length and reports whether it's in range
+func arrayIndex(index object.Object, length int) (int, bool) {
+	// big integers are never in range
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		return 0, false
+	}
+
+	i := integer.Value
+	if i < 0 {
+		i += int64(length)
+	}
+
+	if i < 0 || i >= int64(length) {
+		return 0, false
+	}
+
+	return int(i), true
+}
+
+// evalArrayInfixExpression evaluates the operator with two arrays,
+// + concatenates them into a new array
+func evalArrayInfixExpression(operator string, left, right object.Object) object.Object {
+	l, r := left.(*object.Array).Elements, right.(*object.Array).Elements
+	switch operator {
+	case "+":
+		elements := make([]object.Object, 0, len(l)+len(r))
+		elements = append(elements, l...)
+		elements = append(elements, r...)
+
+		return &object.Array{Elements: elements}
+	case "==":
+		return nativeBoolToBooleanObject(left == right)
+	case "!=":
+		return nativeBoolToBooleanObject(left != right)
+	default:
+		return newUnknownOperatorError(operator, left, right)
+	}
+}
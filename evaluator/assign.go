@@ -0,0 +1,121 @@
+package evaluator
+
+import (
+	"strings"
+
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/object"
+)
+
+// evalAssignExpression updates an existing variable or an element
+// of an array or a hash and evaluates to the assigned value,
+// compound operators (+= -= *= /=) combine the current value with the new one
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	compound := node.Operator != "="
+
+	return assign(node.Target, env, compound, func(current object.Object) object.Object {
+		val := Eval(node.Value, env)
+		if isAbrupt(val) || node.Operator == "=" {
+			return val
+		}
+
+		// += is + and so on
+		return evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val)
+	})
+}
+
+// assign stores the result of update (which is given the current value)
+// in the target and returns it, unless it's an error,
+// compound updates need the current value, so the target has to exist already
+func assign(target ast.Expression, env *object.Environment, compound bool, update func(object.Object) object.Object) object.Object {
+	switch target := target.(type) {
+	case *ast.Identifier:
+		return assignIdentifier(target, env, update)
+	case *ast.IndexExpression:
+		return assignIndex(target, env, compound, update)
+	default:
+		return newError("cannot assign to %s", target.String())
+	}
+}
+
+// assignIdentifier assigns to a variable in the closest scope it's bound in,
+// variables have to be declared with let before they can be assigned to
+// x = 5
+func assignIdentifier(target *ast.Identifier, env *object.Environment, update func(object.Object) object.Object) object.Object {
+	current, ok := env.Get(target.Value)
+	if !ok {
+		return newError("assignment to undeclared identifier: %s", target.Value)
+	}
+
+	val := update(current)
+	if isAbrupt(val) {
+		return val
+	}
+
+	env.Assign(target.Value, val)
+
+	return val
+}
+
+// assignIndex assigns to an element of an array (which has to exist)
+// or to a key of a hash (which has to exist for compound updates),
+// they're both updated in place
+// arr[0] = 5
+// hash["key"] = 5
+func assignIndex(target *ast.IndexExpression, env *object.Environment, compound bool, update func(object.Object) object.Object) object.Object {
+	left := Eval(target.Left, env)
+	if isAbrupt(left) {
+		return left
+	}
+
+	index := Eval(target.Index, env)
+	if isAbrupt(index) {
+		return index
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		if !isInteger(index) {
+			return newError("array index must be an integer, got %s", index.Type())
+		}
+
+		i, ok := arrayIndex(index, len(left.Elements))
+		if !ok {
+			return newError("index out of range: %s (length %d)", index.Inspect(), len(left.Elements))
+		}
+
+		val := update(left.Elements[i])
+		if isAbrupt(val) {
+			return val
+		}
+
+		left.Elements[i] = val
+
+		return val
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return newError("unusable as hash key: %s", index.Type())
+		}
+
+		current, ok := left.Get(key)
+		if !ok {
+			if compound {
+				return newError("key not found: %s", index.Inspect())
+			}
+
+			current = NULL
+		}
+
+		val := update(current)
+		if isAbrupt(val) {
+			return val
+		}
+
+		left.Set(key, val)
+
+		return val
+	default:
+		return newError("index assignment not supported: %s", left.Type())
+	}
+}
@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"math/big"
+
+	"github.com/fr3fou/monkey/object"
+)
+
+// maxIntegerBits caps the size of the results of ** and <<,
+// anything bigger is reported as an overflow instead of eating up all the memory
+const maxIntegerBits = 1 << 20
+
+// isInteger checks if the object is an integer of any size
+func isInteger(obj object.Object) bool {
+	switch obj.Type() {
+	case object.INTEGER_OBJ, object.BIG_INTEGER_OBJ:
+		return true
+	default:
+		return false
+	}
+}
+
+// toBigInt converts the given integer to a *big.Int
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInteger:
+		return obj.Value
+	default:
+		return new(big.Int)
+	}
+}
+
+// newBigInteger wraps the value in the smallest integer object that can hold it,
+// so results that fit in 64 bits go back to being plain integers
+func newBigInteger(value *big.Int) object.Object {
+	if value.IsInt64() {
+		return &object.Integer{Value: value.Int64()}
+	}
+
+	return &object.BigInteger{Value: value}
+}
+
+// evalBigIntegerInfixExpression evaluates the operator with two integers
+// of any size using arbitrary precision, it's used for big integers and for
+// 64-bit integer arithmetic that would otherwise overflow
+func evalBigIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+	l, r := toBigInt(left), toBigInt(right)
+	switch operator {
+	case "+":
+		return newBigInteger(new(big.Int).Add(l, r))
+	case "-":
+		return newBigInteger(new(big.Int).Sub(l, r))
+	case "*":
+		return newBigInteger(new(big.Int).Mul(l, r))
+	case "/":
+		if r.Sign() == 0 {
+			return newDivisionByZeroError(operator, left, right)
+		}
+
+		// Quo truncates towards zero, just like 64-bit division does
+		return newBigInteger(new(big.Int).Quo(l, r))
+	case "%":
+		if r.Sign() == 0 {
+			return newDivisionByZeroError(operator, left, right)
+		}
+
+		// Rem is the remainder of Quo, so it has the sign of the dividend
+		return newBigInteger(new(big.Int).Rem(l, r))
+	case "**":
+		return evalIntegerPowerExpression(left, right)
+	case "&":
+		return newBigInteger(new(big.Int).And(l, r))
+	case "|":
+		return newBigInteger(new(big.Int).Or(l, r))
+	case "^":
+		return newBigInteger(new(big.Int).Xor(l, r))
+	case "<<", ">>":
+		return evalShiftExpression(operator, left, right)
+	case "<":
+		return nativeBoolToBooleanObject(l.Cmp(r) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(l.Cmp(r) > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(l.Cmp(r) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(l.Cmp(r) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(l.Cmp(r) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(l.Cmp(r) != 0)
+	default:
+		return newUnknownOperatorError(operator, left, right)
+	}
+}
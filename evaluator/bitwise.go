@@ -0,0 +1,70 @@
+package evaluator
+
+import (
+	"math/big"
+
+	"github.com/fr3fou/monkey/object"
+)
+
+// evalBitwiseNotExpression flips all of the bits of an integer,
+// integers behave as if they're in two's complement, so ~x == -x - 1
+func evalBitwiseNotExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		return &object.Integer{
+			Value: ^right.Value,
+		}
+	case *object.BigInteger:
+		return newBigInteger(new(big.Int).Not(right.Value))
+	default:
+		return newUnknownOperatorError("~", right)
+	}
+}
+
+// evalShiftExpression shifts an integer (of any size) to the left or to the right,
+// left shifts never lose any bits - they get promoted to big integers instead,
+// right shifts are arithmetic, so they keep the sign of the integer
+// 1 << 3 == 8
+// -16 >> 2 == -4
+func evalShiftExpression(operator string, left, right object.Object) object.Object {
+	l, n := toBigInt(left), toBigInt(right)
+
+	if n.Sign() < 0 {
+		return newNegativeShiftCountError(operator, left, right)
+	}
+
+	// try to stay within 64 bits first
+	if l, ok := left.(*object.Integer); ok && n.IsInt64() {
+		switch shift := n.Uint64(); {
+		case operator == ">>":
+			return &object.Integer{
+				Value: l.Value >> shift,
+			}
+		case shift < 64 && (l.Value<<shift)>>shift == l.Value:
+			return &object.Integer{
+				Value: l.Value << shift,
+			}
+		}
+	}
+
+	if operator == ">>" {
+		// shifting out all of the bits leaves 0 or -1, depending on the sign
+		shift := uint(l.BitLen())
+		if n.IsInt64() && n.Int64() < int64(shift) {
+			shift = uint(n.Int64())
+		}
+
+		return newBigInteger(new(big.Int).Rsh(l, shift))
+	}
+
+	// 0 stays 0, no matter how far it's shifted
+	if l.Sign() == 0 {
+		return &object.Integer{Value: 0}
+	}
+
+	if !n.IsInt64() || int64(l.BitLen())+n.Int64() > maxIntegerBits {
+		return newOverflowError(operator, left, right)
+	}
+
+	return newBigInteger(new(big.Int).Lsh(l, uint(n.Int64())))
+}
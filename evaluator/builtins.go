@@ -0,0 +1,195 @@
+package evaluator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fr3fou/monkey/object"
+)
+
+var (
+	// builtinsMu guards builtins and output
+	builtinsMu sync.RWMutex
+
+	// builtins holds all of the functions implemented in Go,
+	// they are looked up when an identifier isn't bound in the environment
+	builtins = map[string]*object.Builtin{}
+
+	// output is where builtins like puts write to
+	output io.Writer = os.Stdout
+)
+
+func init() {
+	RegisterBuiltin("len", builtinLen)
+	RegisterBuiltin("first", builtinFirst)
+	RegisterBuiltin("last", builtinLast)
+	RegisterBuiltin("rest", builtinRest)
+	RegisterBuiltin("push", builtinPush)
+	RegisterBuiltin("puts", builtinPuts)
+	RegisterBuiltin("type", builtinType)
+}
+
+// RegisterBuiltin makes the Go function available under the given name
+// to all programs, it replaces any builtin with the same name
+// (user defined bindings still shadow builtins)
+//
+// It's safe to call concurrently, but programs that are already being evaluated
+// might or might not see the new builtin, so it should be called before
+// evaluation starts (e.g. from an init function)
+func RegisterBuiltin(name string, fn object.BuiltinFunction) {
+	builtinsMu.Lock()
+	defer builtinsMu.Unlock()
+
+	builtins[name] = &object.Builtin{Name: name, Fn: fn}
+}
+
+// SetOutput sets the writer that builtins like puts print to
+// (os.Stdout by default), like RegisterBuiltin it affects all programs
+// and should be called before evaluation starts
+func SetOutput(w io.Writer) {
+	builtinsMu.Lock()
+	defer builtinsMu.Unlock()
+
+	output = w
+}
+
+// lookupBuiltin returns the builtin registered under the given name
+func lookupBuiltin(name string) (*object.Builtin, bool) {
+	builtinsMu.RLock()
+	defer builtinsMu.RUnlock()
+
+	builtin, ok := builtins[name]
+	return builtin, ok
+}
+
+// len(x) returns the length of a string (in characters), array or hash
+func builtinLen(args ...object.Object) object.Object {
+	if err := checkArgumentCount(args, 1); err != nil {
+		return err
+	}
+
+	switch arg := args[0].(type) {
+	case *object.String:
+		return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
+	case *object.Array:
+		return &object.Integer{Value: int64(len(arg.Elements))}
+	case *object.Hash:
+		return &object.Integer{Value: int64(arg.Len())}
+	default:
+		return newError("argument to `len` not supported, got %s", arg.Type())
+	}
+}
+
+// first(array) returns the first element of the array or null if it's empty
+func builtinFirst(args ...object.Object) object.Object {
+	arr, err := arrayArgument("first", args)
+	if err != nil {
+		return err
+	}
+
+	if len(arr.Elements) == 0 {
+		return NULL
+	}
+
+	return arr.Elements[0]
+}
+
+// last(array) returns the last element of the array or null if it's empty
+func builtinLast(args ...object.Object) object.Object {
+	arr, err := arrayArgument("last", args)
+	if err != nil {
+		return err
+	}
+
+	if len(arr.Elements) == 0 {
+		return NULL
+	}
+
+	return arr.Elements[len(arr.Elements)-1]
+}
+
+// rest(array) returns a new array with every element except the first one
+// or null if it's empty
+func builtinRest(args ...object.Object) object.Object {
+	arr, err := arrayArgument("rest", args)
+	if err != nil {
+		return err
+	}
+
+	if len(arr.Elements) == 0 {
+		return NULL
+	}
+
+	elements := make([]object.Object, len(arr.Elements)-1)
+	copy(elements, arr.Elements[1:])
+
+	return &object.Array{Elements: elements}
+}
+
+// push(array, x) returns a new array with x added to the end,
+// the original array is left as is
+func builtinPush(args ...object.Object) object.Object {
+	if err := checkArgumentCount(args, 2); err != nil {
+		return err
+	}
+
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return newError("argument to `push` must be ARRAY, got %s", args[0].Type())
+	}
+
+	elements := make([]object.Object, len(arr.Elements), len(arr.Elements)+1)
+	copy(elements, arr.Elements)
+
+	return &object.Array{Elements: append(elements, args[1])}
+}
+
+// puts(...) prints every argument on a separate line
+func builtinPuts(args ...object.Object) object.Object {
+	builtinsMu.RLock()
+	w := output
+	builtinsMu.RUnlock()
+
+	for _, arg := range args {
+		fmt.Fprintln(w, arg.Inspect())
+	}
+
+	return NULL
+}
+
+// type(x) returns the name of the type of x
+func builtinType(args ...object.Object) object.Object {
+	if err := checkArgumentCount(args, 1); err != nil {
+		return err
+	}
+
+	return &object.String{Value: string(args[0].Type())}
+}
+
+// checkArgumentCount is a helper function that makes sure
+// a builtin was called with the right amount of arguments
+func checkArgumentCount(args []object.Object, want int) *object.Error {
+	if len(args) != want {
+		return newError("wrong number of arguments: want=%d, got=%d", want, len(args))
+	}
+
+	return nil
+}
+
+// arrayArgument is a helper function for builtins
+// that take in a single array
+func arrayArgument(name string, args []object.Object) (*object.Array, *object.Error) {
+	if err := checkArgumentCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, newError("argument to `%s` must be ARRAY, got %s", name, args[0].Type())
+	}
+
+	return arr, nil
+}
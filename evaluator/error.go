@@ -0,0 +1,145 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fr3fou/monkey/object"
+	"github.com/fr3fou/monkey/token"
+)
+
+// newError is a helper function that formats a new runtime error
+func newError(format string, a ...interface{}) *object.Error {
+	return &object.Error{
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// newUnknownOperatorError is used when the operator
+// isn't supported for the types of the given operands
+// -true
+// true + false
+func newUnknownOperatorError(operator string, operands ...object.Object) *object.Error {
+	var msg string
+
+	if len(operands) == 1 {
+		msg = fmt.Sprintf("unknown operator: %s%s", operator, operands[0].Type())
+	} else {
+		msg = fmt.Sprintf("unknown operator: %s", joinOperands(operator, operands))
+	}
+
+	return &object.Error{
+		Message:  msg,
+		Operator: operator,
+		Operands: operands,
+	}
+}
+
+// newUnknownPostfixOperatorError is used when the postfix
+// operator isn't supported for the type of the operand
+// true++
+func newUnknownPostfixOperatorError(operator string, operand object.Object) *object.Error {
+	return &object.Error{
+		Message:  fmt.Sprintf("unknown operator: %s%s", operand.Type(), operator),
+		Operator: operator,
+		Operands: []object.Object{operand},
+	}
+}
+
+// newTypeMismatchError is used when the operands
+// of an infix expression are of different types
+// 5 + true
+func newTypeMismatchError(operator string, left, right object.Object) *object.Error {
+	operands := []object.Object{left, right}
+
+	return &object.Error{
+		Message:  fmt.Sprintf("type mismatch: %s", joinOperands(operator, operands)),
+		Operator: operator,
+		Operands: operands,
+	}
+}
+
+// newDivisionByZeroError is used when the right operand
+// of a division is zero
+// 5 / 0
+func newDivisionByZeroError(operator string, left, right object.Object) *object.Error {
+	return &object.Error{
+		Message:  "division by zero",
+		Operator: operator,
+		Operands: []object.Object{left, right},
+	}
+}
+
+// newOverflowError is used when the result of
+// the operation is too large to be represented
+// 2 ** 100000000
+// 1 << 100000000
+func newOverflowError(operator string, left, right object.Object) *object.Error {
+	return &object.Error{
+		Message:  fmt.Sprintf("integer overflow: result of %s exceeds %d bits", operator, maxIntegerBits),
+		Operator: operator,
+		Operands: []object.Object{left, right},
+	}
+}
+
+// newNegativeShiftCountError is used when
+// an integer is shifted by a negative amount
+// 1 << -1
+func newNegativeShiftCountError(operator string, left, right object.Object) *object.Error {
+	return &object.Error{
+		Message:  fmt.Sprintf("negative shift count: %s", right.Inspect()),
+		Operator: operator,
+		Operands: []object.Object{left, right},
+	}
+}
+
+// joinOperands formats the types of the operands
+// with the operator in between them - INTEGER + BOOLEAN
+func joinOperands(operator string, operands []object.Object) string {
+	types := []string{}
+	for _, o := range operands {
+		types = append(types, string(o.Type()))
+	}
+
+	return strings.Join(types, " "+operator+" ")
+}
+
+// isAbrupt checks if the given object has to stop the evaluation of the
+// enclosing expression, which is the case for runtime errors, return values
+// and break or continue (e.g. from inside an if that's used as a value),
+// they all have to bubble up
+func isAbrupt(obj object.Object) bool {
+	if obj == nil {
+		return false
+	}
+
+	switch obj.Type() {
+	case object.ERROR_OBJ, object.RETURN_VALUE_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+		return true
+	default:
+		return false
+	}
+}
+
+// withPosition attaches the position of the token to the error,
+// unless it already points at a more specific location
+func withPosition(obj object.Object, tok token.Token) object.Object {
+	if err, ok := obj.(*object.Error); ok && !err.Pos.IsValid() {
+		err.Pos = tok.Pos
+	}
+
+	return obj
+}
+
+// withStackFrame records the call of the function in the stack trace
+// of the error that it resulted in
+func withStackFrame(obj object.Object, fn *object.Function, tok token.Token) object.Object {
+	if err, ok := obj.(*object.Error); ok {
+		err.Stack = append(err.Stack, object.Frame{
+			Function: fn.Name,
+			Pos:      tok.Pos,
+		})
+	}
+
+	return obj
+}
@@ -1,8 +1,12 @@
 package evaluator
 
 import (
+	"math"
+	"math/big"
+
 	"github.com/fr3fou/monkey/ast"
 	"github.com/fr3fou/monkey/object"
+	"github.com/fr3fou/monkey/token"
 )
 
 var (
@@ -15,23 +19,122 @@ var (
 )
 
 // Eval takes in an ast.Node and evaluates it
-func Eval(n ast.Node) object.Object {
+// inside of the provided environment
+func Eval(n ast.Node, env *object.Environment) object.Object {
 	switch node := n.(type) {
 	case *ast.Program:
-		return evalStatements(node.Statements)
+		return evalProgram(node, env)
+	case *ast.BlockStatement:
+		return evalBlockStatement(node, env)
 	case *ast.ExpressionStatement:
-		return Eval(node.Expression)
+		return Eval(node.Expression, env)
+	case *ast.ReturnStatement:
+		val := Eval(node.ReturnValue, env)
+		if isAbrupt(val) {
+			return val
+		}
+
+		return &object.ReturnValue{Value: val}
+	case *ast.FunctionStatement:
+		// the function has already been bound by hoistFunctions,
+		// the declaration itself doesn't have a value
+		return NULL
+	case *ast.LetStatement:
+		val := Eval(node.Value, env)
+		if isAbrupt(val) {
+			return val
+		}
+
+		env.Set(node.Name.Value, val)
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+	case *ast.ForStatement:
+		return evalForStatement(node, env)
+	case *ast.BreakStatement:
+		return &object.Break{Pos: node.Token.Pos}
+	case *ast.ContinueStatement:
+		return &object.Continue{Pos: node.Token.Pos}
+	case *ast.Identifier:
+		return withPosition(evalIdentifier(node, env), node.Token)
 	case *ast.PrefixExpression:
-		right := Eval(node.Right)
-		return evalPrefixExpression(node.Operator, right)
+		right := Eval(node.Right, env)
+		if isAbrupt(right) {
+			return right
+		}
+
+		return withPosition(evalPrefixExpression(node.Operator, right), node.Token)
 	case *ast.InfixExpression:
-		left := Eval(node.Left)
-		right := Eval(node.Right)
-		return evalInfixExpression(node.Operator, left, right)
+		if isLogicalOperator(node.Operator) {
+			return evalLogicalExpression(node, env)
+		}
+
+		left := Eval(node.Left, env)
+		if isAbrupt(left) {
+			return left
+		}
+
+		right := Eval(node.Right, env)
+		if isAbrupt(right) {
+			return right
+		}
+
+		return withPosition(evalInfixExpression(node.Operator, left, right), node.Token)
+	case *ast.ConditionalExpression:
+		return evalConditionalExpression(node, env)
+	case *ast.PostfixExpression:
+		return withPosition(evalPostfixExpression(node, env), node.Token)
+	case *ast.AssignExpression:
+		return withPosition(evalAssignExpression(node, env), node.Token)
 	case *ast.IntegerLiteral:
 		return &object.Integer{Value: node.Value}
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
+	case *ast.StringLiteral:
+		return &object.String{Value: node.Value}
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
+	case *ast.IfExpression:
+		return evalIfExpression(node, env)
+	case *ast.FunctionLiteral:
+		return &object.Function{
+			Parameters: node.Parameters,
+			Body:       node.Body,
+			Env:        env,
+			Name:       node.Name,
+		}
+	case *ast.ArrayLiteral:
+		elements := evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isAbrupt(elements[0]) {
+			return elements[0]
+		}
+
+		return &object.Array{Elements: elements}
+	case *ast.HashLiteral:
+		return evalHashLiteral(node, env)
+	case *ast.IndexExpression:
+		left := Eval(node.Left, env)
+		if isAbrupt(left) {
+			return left
+		}
+
+		index := Eval(node.Index, env)
+		if isAbrupt(index) {
+			return index
+		}
+
+		return withPosition(evalIndexExpression(left, index), node.Token)
+	case *ast.CallExpression:
+		function := Eval(node.Function, env)
+		if isAbrupt(function) {
+			return function
+		}
+
+		args := evalExpressions(node.Arguments, env)
+		if len(args) == 1 && isAbrupt(args[0]) {
+			return args[0]
+		}
+
+		return withPosition(applyFunction(function, args, node.Token), node.Token)
 	}
 
 	return nil
@@ -43,30 +146,203 @@ func evalPrefixExpression(operator string, right object.Object) object.Object {
 		return evalBangOperatorExpression(right)
 	case "-":
 		return evalMinusPrefixExpression(right)
+	case "~":
+		return evalBitwiseNotExpression(right)
 	default:
-		return NULL
+		return newUnknownOperatorError(operator, right)
 	}
 }
 
+// evalInfixExpression evaluates the operator with the given operands
+//
+// == and != are defined for any two objects - booleans and null are
+// singletons, so they (and functions) are compared by identity,
+// and objects of different types are never equal
 func evalInfixExpression(operator string, left, right object.Object) object.Object {
 	switch {
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evalIntegerInfixExpression(operator, left, right)
+	case isInteger(left) && isInteger(right):
+		return evalBigIntegerInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		return evalStringInfixExpression(operator, left, right)
+	case left.Type() == object.ARRAY_OBJ && right.Type() == object.ARRAY_OBJ:
+		return evalArrayInfixExpression(operator, left, right)
+	case operator == "==":
+		return nativeBoolToBooleanObject(left == right)
+	case operator == "!=":
+		return nativeBoolToBooleanObject(left != right)
+	case left.Type() != right.Type():
+		return newTypeMismatchError(operator, left, right)
 	default:
-		return NULL
+		return newUnknownOperatorError(operator, left, right)
 	}
 }
 
-func evalStatements(stmts []ast.Statement) object.Object {
+// evalProgram evaluates all of the top level statements
+// and unwraps the first return value it meets
+func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 
-	for _, statement := range stmts {
-		result = Eval(statement)
+	hoistFunctions(program.Statements, env)
+
+	for _, statement := range program.Statements {
+		result = Eval(statement, env)
+
+		switch result := result.(type) {
+		case *object.ReturnValue:
+			return result.Value
+		case *object.Error:
+			return result
+		case *object.Break, *object.Continue:
+			return unexpectedLoopControl(result)
+		}
 	}
 
 	return result
 }
 
+// evalBlockStatement evaluates the statements inside of a block,
+// stopping at the first return value, break, continue (or error) without unwrapping it,
+// so that it can bubble up to the enclosing loop, function or program
+func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+	var result object.Object
+
+	hoistFunctions(block.Statements, env)
+
+	for _, statement := range block.Statements {
+		result = Eval(statement, env)
+
+		if isAbrupt(result) {
+			return result
+		}
+	}
+
+	return result
+}
+
+// evalExpressions evaluates the expressions from left to right,
+// if any of them results in an error (or returns early), only that is returned
+func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
+	result := []object.Object{}
+
+	for _, e := range exps {
+		evaluated := Eval(e, env)
+		if isAbrupt(evaluated) {
+			return []object.Object{evaluated}
+		}
+
+		result = append(result, evaluated)
+	}
+
+	return result
+}
+
+// applyFunction calls the function with the given arguments
+// inside of a new environment enclosed by the one it was defined in
+// (or straight away for builtins)
+func applyFunction(fn object.Object, args []object.Object, call token.Token) object.Object {
+	switch function := fn.(type) {
+	case *object.Function:
+		if len(args) != len(function.Parameters) {
+			return newError("wrong number of arguments: want=%d, got=%d",
+				len(function.Parameters), len(args))
+		}
+
+		env := extendFunctionEnv(function, args)
+		evaluated := Eval(function.Body, env)
+
+		result := unexpectedLoopControl(unwrapReturnValue(evaluated))
+
+		return withStackFrame(result, function, call)
+	case *object.Builtin:
+		// builtins that don't return anything evaluate to null
+		if result := function.Fn(args...); result != nil {
+			return result
+		}
+
+		return NULL
+	default:
+		return newError("not a function: %s", fn.Type())
+	}
+}
+
+// hoistFunctions binds all of the function declarations
+// in the block upfront, so that they can be called before they're declared
+// (e.g. by each other)
+func hoistFunctions(statements []ast.Statement, env *object.Environment) {
+	for _, statement := range statements {
+		if fs, ok := statement.(*ast.FunctionStatement); ok {
+			env.Set(fs.Name.Value, Eval(fs.Function, env))
+		}
+	}
+}
+
+// extendFunctionEnv binds the arguments to the parameter names
+// in a new scope on top of the function's environment
+func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
+	env := object.NewEnclosedEnvironment(fn.Env)
+
+	for i, param := range fn.Parameters {
+		env.Set(param.Value, args[i])
+	}
+
+	return env
+}
+
+// unwrapReturnValue stops a return value from bubbling up
+// past the function that it was returned from
+func unwrapReturnValue(obj object.Object) object.Object {
+	if returnValue, ok := obj.(*object.ReturnValue); ok {
+		return returnValue.Value
+	}
+
+	// functions with an empty body evaluate to null
+	if obj == nil {
+		return NULL
+	}
+
+	return obj
+}
+
+func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+	condition := Eval(ie.Condition, env)
+	if isAbrupt(condition) {
+		return condition
+	}
+
+	block := ie.Alternative
+	if isTruthy(condition) {
+		block = ie.Consequence
+	}
+
+	if block == nil {
+		return NULL
+	}
+
+	// blocks that are empty (or end with a let) don't have a value
+	if result := Eval(block, env); result != nil {
+		return result
+	}
+
+	return NULL
+}
+
+func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
+	if val, ok := env.Get(node.Value); ok {
+		return val
+	}
+
+	// fall back to the functions implemented in Go
+	if builtin, ok := lookupBuiltin(node.Value); ok {
+		return builtin
+	}
+
+	return newError("identifier not found: %s", node.Value)
+}
+
 func evalBangOperatorExpression(right object.Object) object.Object {
 	switch right {
 	case TRUE:
@@ -81,38 +357,155 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 }
 
 func evalMinusPrefixExpression(right object.Object) object.Object {
-	if right.Type() != object.INTEGER_OBJ {
-		return NULL
-	}
+	switch right := right.(type) {
+	case *object.Integer:
+		// -math.MinInt64 doesn't fit in 64 bits
+		if right.Value == math.MinInt64 {
+			return newBigInteger(new(big.Int).Neg(big.NewInt(right.Value)))
+		}
 
-	val := right.(*object.Integer).Value
-	return &object.Integer{
-		Value: -val,
+		return &object.Integer{
+			Value: -right.Value,
+		}
+	case *object.BigInteger:
+		return newBigInteger(new(big.Int).Neg(right.Value))
+	case *object.Float:
+		return &object.Float{
+			Value: -right.Value,
+		}
+	default:
+		return newUnknownOperatorError("-", right)
 	}
 }
 
+// evalIntegerInfixExpression evaluates the operator with two 64-bit integers,
+// any arithmetic that would overflow is redone with big integers instead
 func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
 	l, r := left.(*object.Integer).Value, right.(*object.Integer).Value
 	switch operator {
 	case "+":
+		sum := l + r
+
+		// the sign flips only when both operands have the same sign
+		if (l >= 0) == (r >= 0) && (sum >= 0) != (l >= 0) {
+			return evalBigIntegerInfixExpression(operator, left, right)
+		}
+
 		return &object.Integer{
-			Value: l + r,
+			Value: sum,
 		}
 	case "-":
+		diff := l - r
+
+		// the sign flips only when the operands have different signs
+		if (l >= 0) != (r >= 0) && (diff >= 0) != (l >= 0) {
+			return evalBigIntegerInfixExpression(operator, left, right)
+		}
+
 		return &object.Integer{
-			Value: l - r,
+			Value: diff,
 		}
 	case "*":
+		product := l * r
+
+		if l != 0 && (product/l != r || l == -1 && r == math.MinInt64) {
+			return evalBigIntegerInfixExpression(operator, left, right)
+		}
+
 		return &object.Integer{
-			Value: l * r,
+			Value: product,
 		}
 	case "/":
+		if r == 0 {
+			return newDivisionByZeroError(operator, left, right)
+		}
+
+		// the only division that overflows
+		if l == math.MinInt64 && r == -1 {
+			return evalBigIntegerInfixExpression(operator, left, right)
+		}
+
 		return &object.Integer{
 			Value: l / r,
 		}
+	case "%":
+		if r == 0 {
+			return newDivisionByZeroError(operator, left, right)
+		}
+
+		// the remainder has the sign of the dividend, just like in Go
+		return &object.Integer{
+			Value: l % r,
+		}
+	case "**":
+		return evalIntegerPowerExpression(left, right)
+	case "&":
+		return &object.Integer{
+			Value: l & r,
+		}
+	case "|":
+		return &object.Integer{
+			Value: l | r,
+		}
+	case "^":
+		return &object.Integer{
+			Value: l ^ r,
+		}
+	case "<<", ">>":
+		return evalShiftExpression(operator, left, right)
+	case "<":
+		return nativeBoolToBooleanObject(l < r)
+	case ">":
+		return nativeBoolToBooleanObject(l > r)
+	case "<=":
+		return nativeBoolToBooleanObject(l <= r)
+	case ">=":
+		return nativeBoolToBooleanObject(l >= r)
+	case "==":
+		return nativeBoolToBooleanObject(l == r)
+	case "!=":
+		return nativeBoolToBooleanObject(l != r)
+	default:
+		return newUnknownOperatorError(operator, left, right)
+	}
+}
 
+// isTruthy reports whether the object counts as true in a condition,
+// everything except false and null is truthy
+func isTruthy(obj object.Object) bool {
+	switch obj {
+	case NULL:
+		return false
+	case TRUE:
+		return true
+	case FALSE:
+		return false
 	default:
-		return NULL
+		return true
+	}
+}
+
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+	l, r := left.(*object.String).Value, right.(*object.String).Value
+	switch operator {
+	case "+":
+		return &object.String{
+			Value: l + r,
+		}
+	case "<":
+		return nativeBoolToBooleanObject(l < r)
+	case ">":
+		return nativeBoolToBooleanObject(l > r)
+	case "<=":
+		return nativeBoolToBooleanObject(l <= r)
+	case ">=":
+		return nativeBoolToBooleanObject(l >= r)
+	case "==":
+		return nativeBoolToBooleanObject(l == r)
+	case "!=":
+		return nativeBoolToBooleanObject(l != r)
+	default:
+		return newUnknownOperatorError(operator, left, right)
 	}
 }
 
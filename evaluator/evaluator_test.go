@@ -1,6 +1,9 @@
 package evaluator
 
 import (
+	"bytes"
+	"math"
+	"os"
 	"testing"
 
 	"github.com/fr3fou/monkey/lexer"
@@ -29,6 +32,39 @@ func TestEvalIntegerExpression(t *testing.T) {
 		{"3 * 3 * 3 + 10", 37},
 		{"3 * (3 * 3) + 10", 37},
 		{"(5 + 10 * 2 + 15 / 3) * 2 + -10", 50},
+		{"0xff + 0b1", 256},
+		{"1_000 * 0o10", 8000},
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"7 % -3", 1},
+		{"2 + 10 % 4 * 3", 8},
+		{"--5", 5},
+		{"5--3", 8},
+		{"let a = 5; let b = 3; a--b + a", 13},
+		{"2 ** 10", 1024},
+		{"2 ** 3 ** 2", 512},
+		{"-2 ** 2", -4},
+		{"(-2) ** 3", -8},
+		{"5 ** 0", 1},
+		{"0 ** 0", 1},
+		{"(-1) ** 9223372036854775807", -1},
+		{"2 * 3 ** 2", 18},
+		{"0b1100 & 0b1010", 8},
+		{"0b1100 | 0b1010", 14},
+		{"0b1100 ^ 0b1010", 6},
+		{"~0", -1},
+		{"~5", -6},
+		{"-1 & 0xff", 0xff},
+		{"1 << 10", 1024},
+		{"-1 << 63", -9223372036854775808},
+		{"1024 >> 3", 128},
+		{"-16 >> 2", -4},
+		{"1 >> 100", 0},
+		{"-1 >> 100", -1},
+		{"0 << 100000000", 0},
+		{"0x12 << 8 | 0x34", 0x1234},
+		{"(0x1234 >> 8) & 0xff", 0x12},
+		{"let crc = 0xffffffff; (crc >> 1) ^ (0xedb88320 & -(crc & 1))", 0x7fffffff ^ 0xedb88320},
 	}
 
 	for _, tt := range tests {
@@ -42,8 +78,9 @@ func testEval(input string) object.Object {
 	p := parser.New(l)
 
 	program := p.ParseProgram()
+	env := object.NewEnvironment()
 
-	return Eval(program)
+	return Eval(program, env)
 }
 
 func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
@@ -72,6 +109,42 @@ func TestEvalBooleanExpression(t *testing.T) {
 
 		{"true", true},
 		{"false", false},
+		{"1 < 2", true},
+		{"1 > 2", false},
+		{"1 < 1", false},
+		{"1 > 1", false},
+		{"-1 < 1", true},
+		{"1 == 1", true},
+		{"1 != 1", false},
+		{"1 == 2", false},
+		{"1 != 2", true},
+		{"1 <= 2", true},
+		{"2 <= 2", true},
+		{"3 <= 2", false},
+		{"1 >= 2", false},
+		{"2 >= 2", true},
+		{"3 >= 2", true},
+		{"1 + 1 >= 2 == 2 <= 1 * 2", true},
+		{"true == true", true},
+		{"false == false", true},
+		{"true == false", false},
+		{"true != false", true},
+		{"false != true", true},
+		{"(1 < 2) == true", true},
+		{"(1 < 2) == false", false},
+		{"(1 > 2) == true", false},
+		{"(1 > 2) == false", true},
+		{"1 + 1 == 2 * 1", true},
+		{"10 / 2 != 5 - 1", true},
+		{"let a = 5; let b = a; a == b", true},
+		{"if (false) { 1 } == if (false) { 2 }", true},
+		{"if (false) { 1 } != if (false) { 2 }", false},
+		{"1 == true", false},
+		{"1 != true", true},
+		{"0 == false", false},
+		{"true == if (false) { 1 }", false},
+		{"let f = fun() { 1 }; f == f", true},
+		{"fun() { 1 } == fun() { 1 }", false},
 	}
 
 	for _, tt := range tests {
@@ -114,3 +187,1517 @@ func TestBangOperator(t *testing.T) {
 		testBooleanObject(t, evaluated, tt.expected)
 	}
 }
+
+func TestLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let a = 5; a;", 5},
+		{"let a = 5 * 5; a;", 25},
+		{"let a = 5; let b = a; b;", 5},
+		{"let a = 5; let b = a; let c = a + b + 5; c;", 15},
+		{"let a = 5; let a = a + 1; a;", 6},
+		{"let число = 5; число * 2;", 10},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestIfElseExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"if (true) { 10 }", 10},
+		{"if (false) { 10 }", nil},
+		{"if (1) { 10 }", 10},
+		{"if (!1) { 10 }", nil},
+		{"if (true) { 10 } else { 20 }", 10},
+		{"if (false) { 10 } else { 20 }", 20},
+		{"if (!!false) { 10 } else { 20 }", 20},
+		{"let x = 5; if (x) { x } else { 0 }", 5},
+		{"if (if (false) { 1 }) { 10 } else { 20 }", 20},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestValuelessBlocks(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"if (true) {}", nil},
+		{"if (true) { let y = 1 }", nil},
+		{"let x = if (true) { let y = 1 }; x", nil},
+		{"let x = if (true) {}; x", nil},
+		{"let x = if (false) { 1 } else {}; x", nil},
+		{"let x = if (true) {}; len([x])", 1},
+		{"let x = if (true) {}; type(x)", "NULL"},
+		{"let x = if (true) {}; puts(x)", nil},
+		{"let x = if (true) {}; x ?? 5", 5},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			testStringObject(t, evaluated, expected)
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"return 10;", 10},
+		{"return 10; 9;", 10},
+		{"return 2 * 5; 9;", 10},
+		{"9; return 2 * 5; 9;", 10},
+		{"if (true) { return 10; }", 10},
+		{
+			`
+if (true) {
+  if (true) {
+    return 10;
+  }
+
+  return 1;
+}
+`,
+			10,
+		},
+		{
+			`
+if (true) {
+  if (false) {
+    return 1;
+  } else {
+    return 10;
+  }
+}
+return 1;
+`,
+			10,
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		testIntegerObject(t, evaluated, tt.expected)
+	}
+}
+
+func TestWhileLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let i = 0; while (i < 10) { let i = i + 1; }; i", 10},
+		{"let i = 0; while (false) { let i = i + 1; }; i", 0},
+		{"let i = 0; let sum = 0; while (i < 5) { let i = i + 1; let sum = sum + i; }; sum", 15},
+		{"let i = 0; while (true) { let i = i + 1; if (i == 3) { break; } }; i", 3},
+		{
+			`let i = 0; let odd = 0;
+while (i < 10) { let i = i + 1; if (i % 2 == 0) { continue; } let odd = odd + 1; };
+odd`,
+			5,
+		},
+		{
+			`let i = 0; let n = 0;
+while (i < 3) { let i = i + 1; let j = 0; while (true) { let j = j + 1; if (j > i) { break; } let n = n + 1; } };
+n`,
+			6,
+		},
+		{"let f = fun() { let i = 0; while (true) { let i = i + 1; if (i == 7) { return i; } } }; f()", 7},
+		{"let i = 0; while (i < 100000) { let i = i + 1; }; i", 100000},
+		{"while (false) { 1 }", nil},
+		// break and continue inside of an if that's used as a value
+		{"let i = 0; while (i < 5) { let i = i + 1; let y = if (i == 2) { break; }; }; i", 2},
+		{
+			`let i = 0; let n = 0;
+while (i < 5) { let i = i + 1; let y = if (i % 2 == 0) { continue; } else { 1 }; let n = n + y; };
+n`,
+			3,
+		},
+		{"let i = 0; while (i < 5) { let i = i + 1; 1 + if (i == 3) { break; } else { 0 } }; i", 3},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else if evaluated != nil {
+			t.Errorf("loop is not nil. got=%T (%+v)", evaluated, evaluated)
+		}
+	}
+}
+
+func TestForLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let find = fun(arr) { for (x in arr) { if (x > 2) { return x; } } }; find([1, 2, 3, 4])", 3},
+		{"let find = fun(arr) { for (x in arr) { if (x > 9) { return x; } } -1 }; find([1, 2, 3, 4])", -1},
+		{"let last = fun(arr) { let result = 0; for (x in arr) { let result = x; } result }; last([1, 2])", 0},
+		{"let f = fun() { for (x in [1, 2, 3, 4]) { if (x < 3) { continue; } return x; } }; f()", 3},
+		{"let f = fun() { for (x in [1, 2, 3]) { if (x == 2) { break; } } 10 }; f()", 10},
+		{`let f = fun(s) { for (c in s) { if (c == "ü") { return 1; } } 0 }; f("grün")`, 1},
+		{`let f = fun(h) { for (k in h) { return h[k]; } }; f({"b": 2, "a": 1})`, 2},
+		{"let f = fun(arr) { for (x in arr) { for (y in arr) { if (x + y == 5) { return x * y; } } } }; f([1, 2, 3])", 6},
+		{"let x = 10; for (x in [1, 2, 3]) { x }; x", 10},
+		{"for (x in []) { 1 }", nil},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else if evaluated != nil {
+			t.Errorf("loop is not nil. got=%T (%+v)", evaluated, evaluated)
+		}
+	}
+}
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let x = 1; x = 5; x", 5},
+		{"let x = 1; x = 5", 5},
+		{"let x = 1; let y = 2; x = y = 3; x + y", 6},
+		{"let x = 10; x += 5; x", 15},
+		{"let x = 10; x -= 5; x", 5},
+		{"let x = 10; x *= 5; x", 50},
+		{"let x = 10; x /= 5; x", 2},
+		{"let x = 1; let f = fun() { x = 2 }; f(); x", 2},
+		{"let x = 1; let f = fun(x) { x = 2 }; f(0); x", 1},
+		{"let counter = fun() { let n = 0; fun() { n += 1 } }; let c = counter(); c(); c(); c()", 3},
+		{"let sum = 0; for (x in [1, 2, 3]) { sum += x; }; sum", 6},
+		{"let i = 0; while (i < 10) { i += 1; }; i", 10},
+		{"let arr = [1, 2, 3]; arr[0] = 5; arr[0]", 5},
+		{"let arr = [1, 2, 3]; arr[-1] *= 10; arr[2]", 30},
+		{"let arr = [1, 2, 3]; let alias = arr; alias[1] = 7; arr[1]", 7},
+		{"let grid = [[1, 2], [3, 4]]; grid[1][0] = 9; grid[1][0]", 9},
+		{`let h = {"a": 1}; h["a"] = 2; h["a"]`, 2},
+		{`let h = {}; h["b"] = 3; h["b"] + len(h)`, 4},
+		{`let h = {"a": 1}; h["a"] += 10; h["a"]`, 11},
+		{`let counts = {}; for (c in "abca") { if (counts[c]) { counts[c] += 1 } else { counts[c] = 1 } } counts["a"]`, 2},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestPostfixExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let x = 1; x++; x", 2},
+		{"let x = 1; x--; x", 0},
+		{"let x = 1; x++", 1},
+		{"let x = 1; x++ + x", 3},
+		{"let x = 1.5; x++; x", 2.5},
+		{"let x = 9223372036854775807; x++; x > 9223372036854775807", true},
+		{"let arr = [1, 2]; arr[1]++; arr[1]", 3},
+		{`let h = {"a": 1}; h["a"]--; h["a"]`, 0},
+		{"let i = 0; while (i < 5) { i++; }; i", 5},
+		{"let n = 0; let f = fun() { n++ }; f(); f(); n", 2},
+		{"5?", 5},
+		{"if (false) { 1 }?; 5", nil},
+		{`let get = fun(h) { let v = h["a"]?; v * 2 }; get({"a": 21})`, 42},
+		// without the ? these would be type mismatches
+		{`let get = fun(h) { let v = h["a"]?; v * 2 }; get({})`, nil},
+		{`let f = fun(h) { h["a"]? + 1 }; f({})`, nil},
+		{`let f = fun(h) { puts(h["a"]?); 1 }; f({})`, nil},
+		{"let f = fun(arr) { for (x in arr) { x? + 1; }; 1 }; f([1, if (false) { 1 }])", nil},
+		{"let f = fun(arr) { for (x in arr) { x?; }; 1 }; f([1, 2])", 1},
+		{"let f = fun() { if (false) { 1 } }; let g = fun() { f()? + 2 }; g()", nil},
+		{"let f = fun() { if (false) { 1 } }; let g = fun() { f()?; 2 }; [g(), 3][1]", 3},
+		{"let f = fun(x) { x * 2 }; let g = fun() { f(2)? + 1 }; g()", 5},
+		{`let h = {"a": 2}; h["a"]? - 1`, 1},
+		{"let x = 3; x? -1", 2},
+		{"let arr = [[5]]; arr?[0]?[0]", 5},
+		{"let f = fun(x) { x * 2 }; let g = fun() { f }; g()?(4)", 8},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case float64:
+			testFloatObject(t, evaluated, expected)
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestForLoopClosures(t *testing.T) {
+	input := `
+let first = 0;
+for (x in [1, 2, 3]) {
+  if (x == 1) { first = fun() { x }; }
+}
+first()`
+
+	// the closure captured the x of its own iteration, not the last one
+	testIntegerObject(t, testEval(input), 1)
+}
+
+func TestFunctionObject(t *testing.T) {
+	input := "fun(x) { x + 2; };"
+
+	evaluated := testEval(input)
+	fn, ok := evaluated.(*object.Function)
+	if !ok {
+		t.Fatalf("object is not Function. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if len(fn.Parameters) != 1 {
+		t.Fatalf("function has wrong parameters. Parameters=%+v",
+			fn.Parameters)
+	}
+
+	if fn.Parameters[0].String() != "x" {
+		t.Fatalf("parameter is not 'x'. got=%q", fn.Parameters[0])
+	}
+
+	expectedBody := "(x + 2)"
+
+	if fn.Body.String() != expectedBody {
+		t.Fatalf("body is not %q. got=%q", expectedBody, fn.Body.String())
+	}
+}
+
+func TestFunctionApplication(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let identity = fun(x) { x; }; identity(5);", 5},
+		{"let identity = fun(x) { return x; }; identity(5);", 5},
+		{"let double = fun(x) { x * 2; }; double(5);", 10},
+		{"let add = fun(x, y) { x + y; }; add(5, 5);", 10},
+		{"let add = fun(x, y) { x + y; }; add(5 + 5, add(5, 5));", 20},
+		{"fun(x) { x; }(5)", 5},
+		{"let f = fun(x) { if (x) { return 1; } return 2; }; f(true) + f(false);", 3},
+		{"let x = 10; let f = fun(x) { x }; f(5) + x;", 15},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestFunctionStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"fun add(x, y) { x + y } add(2, 3)", 5},
+		{"fun add(x, y) { x + y }; add(2, 3)", 5},
+		// declarations are hoisted
+		{"let x = double(4); fun double(x) { x * 2 } x", 8},
+		{
+			`
+fun factorial(n) { if (n == 0) { return 1; } n * factorial(n - 1) }
+factorial(5);`,
+			120,
+		},
+		{
+			`
+fun isEven(n) { if (n == 0) { return true; } isOdd(n - 1) }
+fun isOdd(n) { if (n == 0) { return false; } isEven(n - 1) }
+if (isEven(10) && isOdd(7)) { 1 } else { 0 }`,
+			1,
+		},
+		{
+			`
+fun outer(x) {
+  return inner(x);
+  fun inner(y) { y + x }
+}
+outer(2);`,
+			4,
+		},
+		{
+			`
+let total = 0;
+for (x in [1, 2, 3]) {
+  total += square(x);
+  fun square(n) { n * n }
+}
+total;`,
+			14,
+		},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestFunctionStatementValue(t *testing.T) {
+	tests := []string{
+		"fun f() { 1 }",
+		"if (true) { f(); fun f() { 7 } }",
+		"let x = if (true) { fun ff() { 1 } }; x",
+	}
+
+	for _, input := range tests {
+		testNullObject(t, testEval(input))
+	}
+}
+
+func TestFunctionNames(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fun add(x, y) { x + y } add", "add"},
+		{"let add = fun(x, y) { x + y }; add", "add"},
+		{"let f = fun(x, y) { x + y }; let g = f; g", "f"},
+		{"fun(x, y) { x + y }", ""},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		fn, ok := evaluated.(*object.Function)
+		if !ok {
+			t.Errorf("object is not Function. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if fn.Name != tt.expected {
+			t.Errorf("wrong function name. expected=%q, got=%q", tt.expected, fn.Name)
+		}
+	}
+}
+
+func TestClosures(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{
+			`
+let newAdder = fun(x) {
+  fun(y) { x + y };
+};
+
+let addTwo = newAdder(2);
+addTwo(2);`,
+			4,
+		},
+		{
+			`
+let add = fun(a, b) { a + b };
+let applyFunc = fun(a, b, func) { func(a, b) };
+applyFunc(2, 2, add);`,
+			4,
+		},
+		{
+			`
+let factorial = fun(n) { if (n == 0) { return 1; } n * factorial(n - 1) };
+factorial(5);`,
+			120,
+		},
+		{
+			`
+let curry = fun(f) { fun(a) { fun(b) { f(a, b) } } };
+let mul = fun(a, b) { a * b };
+curry(mul)(3)(4);`,
+			12,
+		},
+		{
+			`
+let compose = fun(f, g) { fun(x) { f(g(x)) } };
+let inc = fun(x) { x + 1 };
+let double = fun(x) { x * 2 };
+compose(inc, double)(5);`,
+			11,
+		},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestErrorHandling(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{
+			"5 + true;",
+			"type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			"5 + true; 5;",
+			"type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			"-true",
+			"unknown operator: -BOOLEAN",
+		},
+		{
+			"true + false;",
+			"unknown operator: BOOLEAN + BOOLEAN",
+		},
+		{
+			"5; true + false; 5",
+			"unknown operator: BOOLEAN + BOOLEAN",
+		},
+		{
+			"if (10) { true + false; }",
+			"unknown operator: BOOLEAN + BOOLEAN",
+		},
+		{
+			`
+if (true) {
+  if (true) {
+    return true + false;
+  }
+
+  return 1;
+}
+`,
+			"unknown operator: BOOLEAN + BOOLEAN",
+		},
+		{
+			"let x = if (true) { let y = 1 }; x + 1",
+			"type mismatch: NULL + INTEGER",
+		},
+		{
+			"let x = if (true) {}; x + 1",
+			"type mismatch: NULL + INTEGER",
+		},
+		{
+			"foobar",
+			"identifier not found: foobar",
+		},
+		{
+			"5 / 0",
+			"division by zero",
+		},
+		{
+			"true < false",
+			"unknown operator: BOOLEAN < BOOLEAN",
+		},
+		{
+			`"Hello" - "World"`,
+			"unknown operator: STRING - STRING",
+		},
+		{
+			`"Hello" + 1`,
+			"type mismatch: STRING + INTEGER",
+		},
+		{
+			"1.5 + true",
+			"type mismatch: FLOAT + BOOLEAN",
+		},
+		{
+			"-true + 1.5",
+			"unknown operator: -BOOLEAN",
+		},
+		{
+			"(9223372036854775807 + 1) / 0",
+			"division by zero",
+		},
+		{
+			"(9223372036854775807 + 1) + true",
+			"type mismatch: BIG_INTEGER + BOOLEAN",
+		},
+		{
+			"[1, 2, 3][3]",
+			"index out of range: 3 (length 3)",
+		},
+		{
+			"[1, 2, 3][-4]",
+			"index out of range: -4 (length 3)",
+		},
+		{
+			"[][0]",
+			"index out of range: 0 (length 0)",
+		},
+		{
+			"[1][9223372036854775807 + 1]",
+			"index out of range: 9223372036854775808 (length 1)",
+		},
+		{
+			`[1]["0"]`,
+			"array index must be an integer, got STRING",
+		},
+		{
+			"5[0]",
+			"index operator not supported: INTEGER",
+		},
+		{
+			"[1] - [1]",
+			"unknown operator: ARRAY - ARRAY",
+		},
+		{
+			"[1, foobar]",
+			"identifier not found: foobar",
+		},
+		{
+			`{"name": "Monkey"}[fun(x) { x }];`,
+			"unusable as hash key: FUNCTION",
+		},
+		{
+			`{[1]: 2}`,
+			"unusable as hash key: ARRAY",
+		},
+		{
+			"let x = if (true) {}; {x: 1}",
+			"unusable as hash key: NULL",
+		},
+		{
+			`{"a": foobar}`,
+			"identifier not found: foobar",
+		},
+		{
+			"true && foobar",
+			"identifier not found: foobar",
+		},
+		{
+			"false || 1 / 0",
+			"division by zero",
+		},
+		{
+			"5 % 0",
+			"division by zero",
+		},
+		{
+			"let big = 2 ** 64; big % 0",
+			"division by zero",
+		},
+		{
+			"2 ** 100000000",
+			"integer overflow: result of ** exceeds 1048576 bits",
+		},
+		{
+			"2 ** (2 ** 64)",
+			"integer overflow: result of ** exceeds 1048576 bits",
+		},
+		{
+			"1 << -1",
+			"negative shift count: -1",
+		},
+		{
+			"let big = 2 ** 64; big >> -2",
+			"negative shift count: -2",
+		},
+		{
+			"1 << 100000000",
+			"integer overflow: result of << exceeds 1048576 bits",
+		},
+		{
+			"1.5 & 1",
+			"unknown operator: FLOAT & INTEGER",
+		},
+		{
+			"~true",
+			"unknown operator: ~BOOLEAN",
+		},
+		{
+			"true | false",
+			"unknown operator: BOOLEAN | BOOLEAN",
+		},
+		{
+			"true ** 2",
+			"type mismatch: BOOLEAN ** INTEGER",
+		},
+		{
+			`"a" % "b"`,
+			"unknown operator: STRING % STRING",
+		},
+		{
+			"for (x in 5) { x }",
+			"not iterable: INTEGER",
+		},
+		{
+			"while (foobar) { 1 }",
+			"identifier not found: foobar",
+		},
+		{
+			"for (x in [1, 2]) { x + true }",
+			"type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			"break;",
+			"break outside of loop",
+		},
+		{
+			"if (true) { continue; }",
+			"continue outside of loop",
+		},
+		{
+			"let f = fun() { break; }; while (true) { f(); }",
+			"break outside of loop",
+		},
+		{
+			"x = 5",
+			"assignment to undeclared identifier: x",
+		},
+		{
+			"len = 5",
+			"assignment to undeclared identifier: len",
+		},
+		{
+			"let x = 1; x += true",
+			"type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			"let x = 1; x /= 0",
+			"division by zero",
+		},
+		{
+			"let arr = [1]; arr[1] = 2",
+			"index out of range: 1 (length 1)",
+		},
+		{
+			`let arr = [1]; arr["a"] = 2`,
+			"array index must be an integer, got STRING",
+		},
+		{
+			"let h = {}; h[[]] = 2",
+			"unusable as hash key: ARRAY",
+		},
+		{
+			`let h = {}; h["a"] += 1`,
+			"key not found: a",
+		},
+		{
+			`let s = "abc"; s[0] = "x"`,
+			"index assignment not supported: STRING",
+		},
+		{
+			`let h = {"a": 1}; h[2] -= 1; h`,
+			"key not found: 2",
+		},
+		{
+			`let h = {"a": 1}; h[2]++; h`,
+			"key not found: 2",
+		},
+		{
+			"let x = 1; x = foobar",
+			"identifier not found: foobar",
+		},
+		{
+			"x++",
+			"assignment to undeclared identifier: x",
+		},
+		{
+			`let s = "a"; s++`,
+			"unknown operator: STRING++",
+		},
+		{
+			"let arr = [true]; arr[0]--",
+			"unknown operator: BOOLEAN--",
+		},
+		{
+			"foobar?",
+			"identifier not found: foobar",
+		},
+		{
+			"let f = fun() { 1 / 0 }; let g = fun() { f()?; 2 }; g()",
+			"division by zero",
+		},
+		{
+			"false ? 1 : foobar",
+			"identifier not found: foobar",
+		},
+		{
+			"(1 / 0) ? 1 : 2",
+			"division by zero",
+		},
+		{
+			"if (false) { 1 } ?? foobar",
+			"identifier not found: foobar",
+		},
+		{
+			"let x = if (true) { fun ff() { 1 } }; len(x)",
+			"argument to `len` not supported, got NULL",
+		},
+		{
+			"5 > true",
+			"type mismatch: INTEGER > BOOLEAN",
+		},
+		{
+			"if (false) { 1 } < if (false) { 1 }",
+			"unknown operator: NULL < NULL",
+		},
+		{
+			"let f = fun(x) { x / 0 }; f(1) + 1;",
+			"division by zero",
+		},
+		{
+			"5(1)",
+			"not a function: INTEGER",
+		},
+		{
+			"let add = fun(x, y) { x + y }; add(1);",
+			"wrong number of arguments: want=2, got=1",
+		},
+		{
+			"let f = fun(x) { x }; f(foobar, 1 / 0);",
+			"identifier not found: foobar",
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)",
+				evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q",
+				tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestErrorOperands(t *testing.T) {
+	evaluated := testEval("5 + true")
+
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+
+	if errObj.Operator != "+" {
+		t.Errorf("wrong error operator. expected=%q, got=%q", "+", errObj.Operator)
+	}
+
+	if len(errObj.Operands) != 2 {
+		t.Fatalf("wrong number of error operands. expected=2, got=%d",
+			len(errObj.Operands))
+	}
+
+	testIntegerObject(t, errObj.Operands[0], 5)
+	testBooleanObject(t, errObj.Operands[1], true)
+}
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedInspect string
+	}{
+		{"5 + true", "ERROR: 1:3: type mismatch: INTEGER + BOOLEAN"},
+		{"let x = 1;\nlet y = -true;", "ERROR: 2:9: unknown operator: -BOOLEAN"},
+		{"let f = fun(x) {\n  x / 0\n};\nf(1) + 1", "ERROR: 2:5: division by zero\n  at f (called from 4:2)"},
+		{"while (true) {\n  let f = fun() { break };\n  f()\n}", "ERROR: 2:19: break outside of loop\n  at f (called from 3:4)"},
+		{
+			"fun inner(x) {\n  x / 0\n}\nfun outer() { inner(1) }\nouter()",
+			"ERROR: 2:5: division by zero\n  at inner (called from 4:20)\n  at outer (called from 5:6)",
+		},
+		{"fun(x) { -true }(1)", "ERROR: 1:10: unknown operator: -BOOLEAN\n  at <anonymous> (called from 1:17)"},
+		{
+			"fun f() { 1 / 0 }\nfun g() { f()? }\ng()",
+			"ERROR: 1:13: division by zero\n  at f (called from 2:12)\n  at g (called from 3:2)",
+		},
+		{"for (x in\n  5) {}", "ERROR: 1:1: not iterable: INTEGER"},
+		{"1 + foobar", "ERROR: 1:5: identifier not found: foobar"},
+		{"let f = fun(x) { x };\nf()", "ERROR: 2:2: wrong number of arguments: want=1, got=0"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)",
+				evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Inspect() != tt.expectedInspect {
+			t.Errorf("wrong error. expected=%q, got=%q",
+				tt.expectedInspect, errObj.Inspect())
+		}
+	}
+}
+
+func TestStringLiteral(t *testing.T) {
+	input := `"Hello\tWorld!"`
+
+	evaluated := testEval(input)
+	testStringObject(t, evaluated, "Hello\tWorld!")
+}
+
+func TestStringConcatenation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"Hello" + " " + "World!"`, "Hello World!"},
+		{`let greet = fun(name) { "Здравей, " + name + "!" }; greet("свят")`, "Здравей, свят!"},
+		{`"" + ""`, ""},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestStringComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`"a" == "a"`, true},
+		{`"a" == "b"`, false},
+		{`"a" != "b"`, true},
+		{`"a" != "a"`, false},
+		{`"a" < "b"`, true},
+		{`"b" < "a"`, false},
+		{`"abc" > "abb"`, true},
+		{`"ab" > "abc"`, false},
+		{`"a" <= "a"`, true},
+		{`"b" <= "a"`, false},
+		{`"abc" >= "abb"`, true},
+		{`"1" == 1`, false},
+		{`"true" != true`, true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func testStringObject(t *testing.T, obj object.Object, expected string) bool {
+	result, ok := obj.(*object.String)
+	if !ok {
+		t.Errorf("object is not String. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%q, want=%q",
+			result.Value, expected)
+		return false
+	}
+
+	return true
+}
+
+func TestEvalFloatExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14", 3.14},
+		{"-2.5", -2.5},
+		{"1e3", 1000},
+		{"0.1 + 0.2", 0.1 + 0.2},
+		{"1.5 * 2", 3},
+		{"2 * 1.5", 3},
+		{"7 / 2.0", 3.5},
+		{"1 - 0.5", 0.5},
+		{"(1 + 2.5) * -2", -7},
+		{"let rate = 0.05; let total = 1000; total * (1 + rate)", 1050},
+		{"7.5 % 2", 1.5},
+		{"-7.5 % 2", -1.5},
+		{"2 ** -1", 0.5},
+		{"2 ** -2", 0.25},
+		{"4 ** 0.5", 2},
+		{"2.0 ** 3", 8},
+		{"1000 * (1 + 0.05) ** 2", 1102.5},
+	}
+
+	for _, tt := range tests {
+		testFloatObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestFloatSpecialValues(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedInspect string
+	}{
+		{"1.0 / 0", "+Inf"},
+		{"-1 / 0.0", "-Inf"},
+		{"0.0 / 0", "NaN"},
+		{"1.0 / 0 - 1.0 / 0", "NaN"},
+		{"1.5 % 0", "NaN"},
+		{"0 ** -1", "+Inf"},
+		{"2.0", "2.0"},
+		{"-0.5", "-0.5"},
+		{"1e21", "1e+21"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if evaluated.Type() != object.FLOAT_OBJ {
+			t.Errorf("object is not Float. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expectedInspect {
+			t.Errorf("wrong inspect. expected=%q, got=%q",
+				tt.expectedInspect, evaluated.Inspect())
+		}
+	}
+}
+
+func TestFloatComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1.5 < 2", true},
+		{"2 > 1.5", true},
+		{"1 == 1.0", true},
+		{"1.0 != 1", false},
+		{"0.1 + 0.2 == 0.3", false},
+		{"let nan = 0.0 / 0; nan == nan", false},
+		{"let nan = 0.0 / 0; nan != nan", true},
+		{"let nan = 0.0 / 0; nan < 1 == nan > 1", true},
+		{"1.0 / 0 > 1e308", true},
+		{"1.5 <= 1.5", true},
+		{"2 >= 2.5", false},
+		{"let nan = 0.0 / 0; nan <= nan", false},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func testFloatObject(t *testing.T, obj object.Object, expected float64) bool {
+	result, ok := obj.(*object.Float)
+	if !ok {
+		t.Errorf("object is not Float. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if math.Abs(result.Value-expected) > 1e-9 {
+		t.Errorf("object has wrong value. got=%g, want=%g",
+			result.Value, expected)
+		return false
+	}
+
+	return true
+}
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"-9223372036854775807 - 1 - 1", "-9223372036854775809"},
+		{"1 - -9223372036854775807 + 1", "9223372036854775809"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"-1 * (-9223372036854775807 - 1)", "9223372036854775808"},
+		{"(-9223372036854775807 - 1) * -1", "9223372036854775808"},
+		{"(-9223372036854775807 - 1) / -1", "9223372036854775808"},
+		{"-(-9223372036854775807 - 1)", "9223372036854775808"},
+		{"let big = 9223372036854775807 * 10; big * big", "8507059173023461584739690778423250124900"},
+		{"let big = 9223372036854775807 * 10; -big", "-92233720368547758070"},
+		{"let big = 9223372036854775807 * 10; 5 - big", "-92233720368547758065"},
+		{"2 ** 64", "18446744073709551616"},
+		{"(-3) ** 41", "-36472996377170786403"},
+		{"let big = 2 ** 64; big ** 2", "340282366920938463463374607431768211456"},
+		{"1 << 63", "9223372036854775808"},
+		{"3 << 64", "55340232221128654848"},
+		{"let big = 2 ** 64; big | 1", "18446744073709551617"},
+		{"let big = 2 ** 64; ~big", "-18446744073709551617"},
+		{"let big = 2 ** 100; big >> 10", "1237940039285380274899124224"},
+		{
+			`
+let factorial = fun(n) { if (n == 0) { return 1; } n * factorial(n - 1) };
+factorial(25);`,
+			"15511210043330985984000000",
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		result, ok := evaluated.(*object.BigInteger)
+		if !ok {
+			t.Errorf("object is not BigInteger. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if result.Inspect() != tt.expected {
+			t.Errorf("object has wrong value. got=%s, want=%s",
+				result.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestBigIntegerNormalization(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"9223372036854775807 + 1 - 1", 9223372036854775807},
+		{"let big = 9223372036854775807 * 4; big / 4", 9223372036854775807},
+		{"let big = 9223372036854775807 * 4; big - big", 0},
+		{"let big = 9223372036854775807 * 4; big / big", 1},
+		{"-9223372036854775807 - 1", -9223372036854775808},
+		{"9223372036854775807 * 1", 9223372036854775807},
+		{"let big = 2 ** 100; big % 1000", 376},
+		{"let big = 2 ** 100; -big % 1000", -376},
+		{"(2 ** 64) ** 0", 1},
+		{"let big = 2 ** 64; big >> 64", 1},
+		{"let big = 2 ** 64; big & 0xff", 0},
+		{"let big = 2 ** 64; big ^ big", 0},
+		{"let big = -(2 ** 64); big >> 1000", -1},
+		{"let big = 2 ** 64; big >> big", 0},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestBigIntegerComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"let big = 9223372036854775807 + 1; big > 9223372036854775807", true},
+		{"let big = 9223372036854775807 + 1; big < 1", false},
+		{"let big = 9223372036854775807 + 1; big == 9223372036854775807 + 1", true},
+		{"let big = 9223372036854775807 + 1; big != big + 1", true},
+		{"let big = 9223372036854775807 + 1; big == 9223372036854775807", false},
+		{"let big = 9223372036854775807 + 1; big == 9223372036854775808.0", true},
+		{"let big = 9223372036854775807 + 1; big < 1e19", true},
+		{"let big = 9223372036854775807 + 1; big <= big", true},
+		{"let big = 9223372036854775807 + 1; big >= big + 1", false},
+		{"let big = 9223372036854775807 + 1; 1 <= big", true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestArrayLiterals(t *testing.T) {
+	input := "[1, 2 * 2, 3 + 3]"
+
+	evaluated := testEval(input)
+	result, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if len(result.Elements) != 3 {
+		t.Fatalf("array has wrong num of elements. got=%d",
+			len(result.Elements))
+	}
+
+	testIntegerObject(t, result.Elements[0], 1)
+	testIntegerObject(t, result.Elements[1], 4)
+	testIntegerObject(t, result.Elements[2], 6)
+
+	if result.Inspect() != "[1, 4, 6]" {
+		t.Errorf("wrong inspect. got=%q", result.Inspect())
+	}
+}
+
+func TestArrayIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"[1, 2, 3][0]", 1},
+		{"[1, 2, 3][1]", 2},
+		{"[1, 2, 3][2]", 3},
+		{"let i = 0; [1][i];", 1},
+		{"[1, 2, 3][1 + 1];", 3},
+		{"let myArray = [1, 2, 3]; myArray[2];", 3},
+		{"let myArray = [1, 2, 3]; myArray[0] + myArray[1] + myArray[2];", 6},
+		{"let myArray = [1, 2, 3]; let i = myArray[0]; myArray[i]", 2},
+		{"[1, 2, 3][-1]", 3},
+		{"[1, 2, 3][-3]", 1},
+		{"[[1, 2], [3, 4]][1][0]", 3},
+		{"let f = fun() { [fun(x) { x * 2 }] }; f()[0](21)", 42},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestArrayConcatenation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2] + [3]", "[1, 2, 3]"},
+		{"[] + []", "[]"},
+		{`let a = [1]; let b = a + ["two", true]; a + b`, "[1, 1, two, true]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		if evaluated.Type() != object.ARRAY_OBJ {
+			t.Errorf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong inspect. expected=%q, got=%q", tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestHashLiterals(t *testing.T) {
+	input := `let two = "two";
+	{
+		"one": 10 - 9,
+		two: 1 + 1,
+		"thr" + "ee": 6 / 2,
+		4: 4,
+		true: 5,
+		false: 6,
+		9223372036854775807 + 1: 7
+	}`
+
+	evaluated := testEval(input)
+	result, ok := evaluated.(*object.Hash)
+	if !ok {
+		t.Fatalf("Eval didn't return Hash. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	expected := []struct {
+		key   object.Hashable
+		value int64
+	}{
+		{&object.String{Value: "one"}, 1},
+		{&object.String{Value: "two"}, 2},
+		{&object.String{Value: "three"}, 3},
+		{&object.Integer{Value: 4}, 4},
+		{TRUE, 5},
+		{FALSE, 6},
+		{testEval("9223372036854775807 + 1").(object.Hashable), 7},
+	}
+
+	if result.Len() != len(expected) {
+		t.Fatalf("Hash has wrong num of pairs. got=%d", result.Len())
+	}
+
+	for i, pair := range result.Pairs() {
+		if pair.Key.Inspect() != expected[i].key.Inspect() {
+			t.Errorf("pairs[%d] has wrong key. want=%s, got=%s",
+				i, expected[i].key.Inspect(), pair.Key.Inspect())
+		}
+
+		value, ok := result.Get(expected[i].key)
+		if !ok {
+			t.Errorf("no value for given key %s in Pairs", expected[i].key.Inspect())
+			continue
+		}
+
+		testIntegerObject(t, value, expected[i].value)
+	}
+
+	expectedInspect := "{one: 1, two: 2, three: 3, 4: 4, true: 5, false: 6, 9223372036854775808: 7}"
+	if result.Inspect() != expectedInspect {
+		t.Errorf("wrong inspect. expected=%q, got=%q", expectedInspect, result.Inspect())
+	}
+}
+
+func TestHashKeys(t *testing.T) {
+	hello1 := &object.String{Value: "Hello World"}
+	hello2 := &object.String{Value: "Hello World"}
+	diff := &object.String{Value: "My name is johnny"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if hello1.HashKey() == diff.HashKey() {
+		t.Errorf("strings with different content have same hash keys")
+	}
+
+	one := &object.Integer{Value: 1}
+	if one.HashKey() == TRUE.HashKey() {
+		t.Errorf("objects of different types have same hash keys")
+	}
+}
+
+func TestHashIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{"foo": 5}["foo"]`, 5},
+		{`{"foo": 5}["bar"]`, nil},
+		{`let key = "foo"; {"foo": 5}[key]`, 5},
+		{`{}["foo"]`, nil},
+		{`{5: 5}[5]`, 5},
+		{`{true: 5}[true]`, 5},
+		{`{false: 5}[false]`, 5},
+		{`{"a": 1, "a": 2}["a"]`, 2},
+		{`let config = {"db": {"port": 5432}}; config["db"]["port"]`, 5432},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestBuiltinFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`len("")`, 0},
+		{`len("four")`, 4},
+		{`len("hello world")`, 11},
+		{`len("здравей")`, 7},
+		{`len([1, 2, 3])`, 3},
+		{`len([])`, 0},
+		{`len({"a": 1, "b": 2})`, 2},
+		{`len(1)`, "argument to `len` not supported, got INTEGER"},
+		{`len("one", "two")`, "wrong number of arguments: want=1, got=2"},
+		{`first([1, 2, 3])`, 1},
+		{`first([])`, nil},
+		{`first(1)`, "argument to `first` must be ARRAY, got INTEGER"},
+		{`last([1, 2, 3])`, 3},
+		{`last([])`, nil},
+		{`last(1)`, "argument to `last` must be ARRAY, got INTEGER"},
+		{`rest([1, 2, 3])`, []int64{2, 3}},
+		{`rest([1])`, []int64{}},
+		{`rest([])`, nil},
+		{`push([], 1)`, []int64{1}},
+		{`let a = [1]; push(a, 2); a`, []int64{1}},
+		{`push(1, 1)`, "argument to `push` must be ARRAY, got INTEGER"},
+		{`push([1])`, "wrong number of arguments: want=2, got=1"},
+		{`puts()`, nil},
+		{`type(1)`, "INTEGER"},
+		{`type("a")`, "STRING"},
+		{`type([])`, "ARRAY"},
+		{`type({})`, "HASH"},
+		{`type(len)`, "BUILTIN"},
+		{`type(fun() {})`, "FUNCTION"},
+		{`type(if (false) { 1 })`, "NULL"},
+		{`let len = fun(x) { 42 }; len([])`, 42},
+		{
+			`
+let map = fun(arr, f) {
+  let iter = fun(arr, acc) {
+    if (len(arr) == 0) { acc } else { iter(rest(arr), push(acc, f(first(arr)))) }
+  };
+  iter(arr, []);
+};
+map([1, 2, 3], fun(x) { x * 2 })`,
+			[]int64{2, 4, 6},
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			testNullObject(t, evaluated)
+		case string:
+			switch evaluated := evaluated.(type) {
+			case *object.Error:
+				if evaluated.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q",
+						expected, evaluated.Message)
+				}
+			default:
+				testStringObject(t, evaluated, expected)
+			}
+		case []int64:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("obj not Array. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if len(array.Elements) != len(expected) {
+				t.Errorf("wrong num of elements. want=%d, got=%d",
+					len(expected), len(array.Elements))
+				continue
+			}
+
+			for i, expectedElem := range expected {
+				testIntegerObject(t, array.Elements[i], expectedElem)
+			}
+		}
+	}
+}
+
+func TestRegisterBuiltin(t *testing.T) {
+	RegisterBuiltin("double", func(args ...object.Object) object.Object {
+		return &object.Integer{Value: args[0].(*object.Integer).Value * 2}
+	})
+	defer delete(builtins, "double")
+
+	RegisterBuiltin("nothing", func(args ...object.Object) object.Object {
+		return nil
+	})
+	defer delete(builtins, "nothing")
+
+	testIntegerObject(t, testEval("double(21)"), 42)
+	testNullObject(t, testEval("nothing()"))
+}
+
+func TestSetOutput(t *testing.T) {
+	var out bytes.Buffer
+
+	SetOutput(&out)
+	defer SetOutput(os.Stdout)
+
+	testNullObject(t, testEval(`puts("a", 1, [true])`))
+
+	expected := "a\n1\n[true]\n"
+	if out.String() != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, out.String())
+	}
+}
+
+func TestLogicalOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"true && true", true},
+		{"true && false", false},
+		{"false && true", false},
+		{"false && false", false},
+		{"true || false", true},
+		{"false || true", true},
+		{"false || false", false},
+		{"1 && \"a\"", true},
+		{"false || 0", true},
+		{"if (false) { 1 } || false", false},
+		{"1 < 2 && 2 < 3", true},
+		{"1 < 2 && 2 > 3 || 4 == 4", true},
+		{"!(true && false)", true},
+		// the right operand must not be evaluated
+		{"false && foobar", false},
+		{"true || 1 / 0", true},
+		{"let f = fun() { 1 / 0 }; false && f()", false},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestConditionalExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"true ? 1 : 2", 1},
+		{"false ? 1 : 2", 2},
+		{"0 ? 1 : 2", 1},
+		{"if (false) { 1 } ? 1 : 2", 2},
+		{"let x = -5; x > 0 ? x : -x", 5},
+		{"let x = 0; x < 0 ? -1 : x == 0 ? 0 : 1", 0},
+		{"let max = fun(a, b) { a > b ? a : b }; max(3, 7)", 7},
+		{"true ? if (false) { 1 } : 2", nil},
+		{"let c = true; c ? -1 : 1", -1},
+		{"let x = 4; false ? (x) : [x][0]", 4},
+		// only the chosen branch is evaluated
+		{"true ? 1 : 1 / 0", 1},
+		{"false ? foobar : 2", 2},
+		{"let x = 1; true ? x++ : x--; x", 2},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestCoalesceOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"1 ?? 2", 1},
+		{"if (false) { 1 } ?? 2", 2},
+		{"false ?? 2", false},
+		{"0 ?? 2", 0},
+		{`let h = {"a": 1}; h["b"] ?? 0`, 0},
+		{`let h = {"a": 1}; h["a"] ?? 0`, 1},
+		{"if (false) { 1 } ?? if (false) { 2 } ?? 3", 3},
+		{"if (false) { 1 } ?? if (false) { 2 }", nil},
+		// the right operand must not be evaluated
+		{"1 ?? foobar", 1},
+		{"let x = 1; 5 ?? x++; x", 1},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func testNullObject(t *testing.T, obj object.Object) bool {
+	if obj != NULL {
+		t.Errorf("object is not NULL. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	return true
+}
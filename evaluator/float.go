@@ -0,0 +1,82 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/fr3fou/monkey/object"
+)
+
+// isNumber checks if the object is an integer (of any size) or a float
+func isNumber(obj object.Object) bool {
+	switch obj.Type() {
+	case object.INTEGER_OBJ, object.BIG_INTEGER_OBJ, object.FLOAT_OBJ:
+		return true
+	default:
+		return false
+	}
+}
+
+// toFloat promotes the given number to a float64
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInteger:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return f
+	case *object.Float:
+		return obj.Value
+	default:
+		return 0
+	}
+}
+
+// evalFloatInfixExpression evaluates the operator with two numbers,
+// at least one of which is a float - the other one gets promoted to a float
+//
+// Floats follow IEEE 754, so dividing by zero results in ±Inf (or NaN for 0 / 0 and x % 0)
+// and NaN isn't equal to anything, including itself
+func evalFloatInfixExpression(operator string, left, right object.Object) object.Object {
+	l, r := toFloat(left), toFloat(right)
+	switch operator {
+	case "+":
+		return &object.Float{
+			Value: l + r,
+		}
+	case "-":
+		return &object.Float{
+			Value: l - r,
+		}
+	case "*":
+		return &object.Float{
+			Value: l * r,
+		}
+	case "/":
+		return &object.Float{
+			Value: l / r,
+		}
+	case "%":
+		return &object.Float{
+			Value: math.Mod(l, r),
+		}
+	case "**":
+		return &object.Float{
+			Value: math.Pow(l, r),
+		}
+	case "<":
+		return nativeBoolToBooleanObject(l < r)
+	case ">":
+		return nativeBoolToBooleanObject(l > r)
+	case "<=":
+		return nativeBoolToBooleanObject(l <= r)
+	case ">=":
+		return nativeBoolToBooleanObject(l >= r)
+	case "==":
+		return nativeBoolToBooleanObject(l == r)
+	case "!=":
+		return nativeBoolToBooleanObject(l != r)
+	default:
+		return newUnknownOperatorError(operator, left, right)
+	}
+}
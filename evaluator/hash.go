@@ -0,0 +1,49 @@
+package evaluator
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/object"
+)
+
+// evalHashLiteral evaluates the pairs of the hash in order
+func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
+	hash := object.NewHash()
+
+	for _, pair := range node.Pairs {
+		key := Eval(pair.Key, env)
+		if isAbrupt(key) {
+			return key
+		}
+
+		hashKey, ok := key.(object.Hashable)
+		if !ok {
+			return withPosition(newError("unusable as hash key: %s", key.Type()), node.Token)
+		}
+
+		value := Eval(pair.Value, env)
+		if isAbrupt(value) {
+			return value
+		}
+
+		hash.Set(hashKey, value)
+	}
+
+	return hash
+}
+
+// evalHashIndexExpression returns the value stored under the key,
+// or null if there isn't one
+// {"one": 1}["one"] // 1
+func evalHashIndexExpression(hash, index object.Object) object.Object {
+	key, ok := index.(object.Hashable)
+	if !ok {
+		return newError("unusable as hash key: %s", index.Type())
+	}
+
+	value, ok := hash.(*object.Hash).Get(key)
+	if !ok {
+		return NULL
+	}
+
+	return value
+}
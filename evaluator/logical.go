@@ -0,0 +1,72 @@
+package evaluator
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/object"
+)
+
+// isLogicalOperator checks if the operator short-circuits,
+// meaning that its right operand shouldn't always be evaluated
+func isLogicalOperator(operator string) bool {
+	return operator == "&&" || operator == "||" || operator == "??"
+}
+
+// evalLogicalExpression evaluates &&, || and ?? with short-circuiting -
+// the right operand is only evaluated when the left one doesn't decide the result
+//
+// The result is always a strict boolean (operands are converted using
+// the same truthiness rules as conditions), not the deciding operand
+// 1 && "a" // true
+// false || 0 // true
+//
+// ?? is the exception, it evaluates to the left operand unless it's null
+// (false is a perfectly fine value), in which case it evaluates to the right one
+// h["missing"] ?? 0 // 0
+// false ?? 0 // false
+func evalLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+	if isAbrupt(left) {
+		return left
+	}
+
+	if node.Operator == "??" {
+		if left != NULL {
+			return left
+		}
+
+		return Eval(node.Right, env)
+	}
+
+	// false && x
+	if node.Operator == "&&" && !isTruthy(left) {
+		return FALSE
+	}
+
+	// true || x
+	if node.Operator == "||" && isTruthy(left) {
+		return TRUE
+	}
+
+	right := Eval(node.Right, env)
+	if isAbrupt(right) {
+		return right
+	}
+
+	return nativeBoolToBooleanObject(isTruthy(right))
+}
+
+// evalConditionalExpression evaluates only one of the branches,
+// depending on the truthiness of the condition
+// x > 0 ? x : -x
+func evalConditionalExpression(node *ast.ConditionalExpression, env *object.Environment) object.Object {
+	condition := Eval(node.Condition, env)
+	if isAbrupt(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(node.Consequence, env)
+	}
+
+	return Eval(node.Alternative, env)
+}
@@ -0,0 +1,110 @@
+package evaluator
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/object"
+)
+
+// evalWhileStatement evaluates the body for as long as the condition is truthy,
+// the body shares the environment of the loop, just like the body of an if does
+func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := Eval(ws.Condition, env)
+		if isAbrupt(condition) {
+			return condition
+		}
+
+		if !isTruthy(condition) {
+			return nil
+		}
+
+		if result, stop := evalLoopBody(ws.Body, env); stop {
+			return result
+		}
+	}
+}
+
+// evalForStatement evaluates the body once for every element of the iterable,
+// each iteration gets its own scope with the loop variable bound in it
+func evalForStatement(fs *ast.ForStatement, env *object.Environment) object.Object {
+	iterable := Eval(fs.Iterable, env)
+	if isAbrupt(iterable) {
+		return iterable
+	}
+
+	elements, ok := iterate(iterable)
+	if !ok {
+		return withPosition(newError("not iterable: %s", iterable.Type()), fs.Token)
+	}
+
+	for _, element := range elements {
+		scope := object.NewEnclosedEnvironment(env)
+		scope.Set(fs.Variable.Value, element)
+
+		if result, stop := evalLoopBody(fs.Body, scope); stop {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// evalLoopBody evaluates a single iteration of a loop
+// and reports whether the loop should stop,
+// return values and errors keep on bubbling up past the loop
+func evalLoopBody(body *ast.BlockStatement, env *object.Environment) (object.Object, bool) {
+	switch result := Eval(body, env).(type) {
+	case *object.Break:
+		return nil, true
+	case *object.ReturnValue, *object.Error:
+		return result, true
+	default:
+		// continue or the end of the body, either way carry on with the next iteration
+		return nil, false
+	}
+}
+
+// iterate returns the elements that a for loop goes over
+// arrays - every element
+// strings - every character
+// hashes - every key, in insertion order
+func iterate(obj object.Object) ([]object.Object, bool) {
+	switch obj := obj.(type) {
+	case *object.Array:
+		// copy the elements, so the loop isn't affected by changes to the array
+		return append([]object.Object{}, obj.Elements...), true
+	case *object.String:
+		elements := []object.Object{}
+		for _, r := range obj.Value {
+			elements = append(elements, &object.String{Value: string(r)})
+		}
+
+		return elements, true
+	case *object.Hash:
+		elements := []object.Object{}
+		for _, pair := range obj.Pairs() {
+			elements = append(elements, pair.Key)
+		}
+
+		return elements, true
+	default:
+		return nil, false
+	}
+}
+
+// unexpectedLoopControl turns a break or continue that made it out of
+// a function or the program into an error, as there is no loop to control
+func unexpectedLoopControl(obj object.Object) object.Object {
+	switch obj := obj.(type) {
+	case *object.Break:
+		err := newError("break outside of loop")
+		err.Pos = obj.Pos
+		return err
+	case *object.Continue:
+		err := newError("continue outside of loop")
+		err.Pos = obj.Pos
+		return err
+	default:
+		return obj
+	}
+}
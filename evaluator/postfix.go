@@ -0,0 +1,58 @@
+package evaluator
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/object"
+)
+
+// evalPostfixExpression evaluates the postfix operators
+//
+// x++ and x-- update a variable (or an element) that holds a number
+// and evaluate to its value from before the update
+//
+// x? evaluates to x, unless it's null - then it returns null
+// from the enclosing function straight away (or stops the program),
+// errors keep on bubbling up, just like they do everywhere else
+// let name = user["name"]?;
+func evalPostfixExpression(node *ast.PostfixExpression, env *object.Environment) object.Object {
+	switch node.Operator {
+	case "++", "--":
+		return evalIncrementExpression(node, env)
+	case "?":
+		left := Eval(node.Left, env)
+		if isAbrupt(left) {
+			return left
+		}
+
+		if left == NULL {
+			return &object.ReturnValue{Value: NULL}
+		}
+
+		return left
+	default:
+		return newError("unknown operator: %s", node.Operator)
+	}
+}
+
+// evalIncrementExpression adds (or subtracts) 1 to the target
+// and returns its previous value
+func evalIncrementExpression(node *ast.PostfixExpression, env *object.Environment) object.Object {
+	var previous object.Object
+
+	result := assign(node.Left, env, true, func(current object.Object) object.Object {
+		if !isNumber(current) {
+			return newUnknownPostfixOperatorError(node.Operator, current)
+		}
+
+		previous = current
+
+		// ++ is + and -- is -
+		return evalInfixExpression(node.Operator[:1], current, &object.Integer{Value: 1})
+	})
+
+	if isAbrupt(result) {
+		return result
+	}
+
+	return previous
+}
@@ -0,0 +1,32 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/fr3fou/monkey/object"
+)
+
+// evalIntegerPowerExpression raises an integer (of any size) to the power of another,
+// negative exponents promote the result to a float, as it's no longer a whole number
+// 2 ** 10 == 1024
+// 2 ** -1 == 0.5
+func evalIntegerPowerExpression(left, right object.Object) object.Object {
+	base, exp := toBigInt(left), toBigInt(right)
+
+	if exp.Sign() < 0 {
+		return &object.Float{
+			Value: math.Pow(toFloat(left), toFloat(right)),
+		}
+	}
+
+	// the result has at least (bits - 1) * exp bits,
+	// 0, 1 and -1 are the only bases that never grow
+	if bits := int64(base.BitLen() - 1); bits > 0 {
+		if !exp.IsInt64() || exp.Int64() > maxIntegerBits/bits {
+			return newOverflowError("**", left, right)
+		}
+	}
+
+	return newBigInteger(new(big.Int).Exp(base, exp, nil))
+}
@@ -0,0 +1,59 @@
+package lexer
+
+import (
+	"strings"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// readLineComment reads a comment starting at the current `//`
+// up until the end of the line (without the new line)
+// // foo bar
+func (l *Lexer) readLineComment() token.Token {
+	var out strings.Builder
+
+	for l.ch != '\n' && l.width > 0 {
+		out.WriteRune(l.ch)
+		l.readChar()
+	}
+
+	return token.Token{Type: token.COMMENT, Literal: out.String()}
+}
+
+// readBlockComment reads a comment starting at the current `/*`
+// up until the matching `*/`, block comments can be nested
+// /* foo /* bar */ baz */
+//
+// Unterminated block comments are ILLEGAL
+func (l *Lexer) readBlockComment() token.Token {
+	var out strings.Builder
+	depth := 0
+
+	for {
+		// reached the end of the input without a closing `*/`
+		if l.width == 0 {
+			return token.Token{Type: token.ILLEGAL, Literal: out.String()}
+		}
+
+		switch {
+		case l.ch == '/' && l.peekChar() == '*':
+			depth++
+		case l.ch == '*' && l.peekChar() == '/':
+			depth--
+		default:
+			out.WriteRune(l.ch)
+			l.readChar()
+			continue
+		}
+
+		// write both characters of the delimiter
+		out.WriteRune(l.ch)
+		l.readChar()
+		out.WriteRune(l.ch)
+		l.readChar()
+
+		if depth == 0 {
+			return token.Token{Type: token.COMMENT, Literal: out.String()}
+		}
+	}
+}
@@ -1,30 +1,64 @@
 package lexer
 
-import "github.com/fr3fou/monkey/token"
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fr3fou/monkey/token"
+)
 
 // Lexer is a struct that
 // takes in an input and returns
 // all of the valid tokens
 type Lexer struct {
-	input string // TODO: should be io.Reader instead
+	r *bufio.Reader
 
-	// pos is a "pointer" to the the current char
-	pos int
+	// err is the first error (other than io.EOF) returned by the reader
+	err error
 
-	// nextPos is a "pointer" to the position after the current char (allows us to "peek")
-	nextPos int
+	// pos is the byte offset of the current char
+	pos int
 
 	// ch is the current character in our lexer
-	ch byte // TODO: should be rune instead - support UTF
+	ch rune
+
+	// width is the amount of bytes the current character takes up
+	width int
+
+	// raw holds the original byte of ch when it isn't valid UTF-8
+	raw string
+
+	// next is the character after the current one (allows us to "peek")
+	next char
+
+	// line and col are the line and column of the current char
+	line int
+	col  int
+
+	// emitComments makes NextToken return comments instead of skipping them
+	emitComments bool
 }
 
 // New returns a pointer to
 // a Lexer struct with the given input
 func New(input string) *Lexer {
+	return NewReader(strings.NewReader(input))
+}
+
+// NewReader returns a pointer to a Lexer struct
+// that incrementally reads its input from r
+func NewReader(r io.Reader) *Lexer {
 	l := &Lexer{
-		input: input,
+		r:    newReader(r),
+		line: 1,
 	}
 
+	// Decode the first char upfront, so there is always one to peek at
+	l.next = l.decodeChar()
+
 	// Call it immediately after creation
 	// so we can make sure we have initialized our lexer
 	l.readChar()
@@ -32,14 +66,44 @@ func New(input string) *Lexer {
 	return l
 }
 
+// Err returns the first error that occurred while reading the input,
+// the lexer treats it as the end of the input
+func (l *Lexer) Err() error {
+	return l.err
+}
+
+// EmitComments makes the lexer return comments as COMMENT tokens
+// (e.g. for formatters), instead of skipping them like whitespace
+func (l *Lexer) EmitComments(emit bool) {
+	l.emitComments = emit
+}
+
 // NextToken advances through our input
 // and returns the actual Token struct for the
 // given character
 func (l *Lexer) NextToken() token.Token {
-	var tok token.Token
+	for {
+		// eat any whitespace chars
+		l.eatWhitespace()
 
-	// eat any whitespace chars
-	l.eatWhitespace()
+		pos := l.position()
+		tok := l.readToken()
+
+		tok.Pos = pos
+		tok.End = l.position()
+
+		// comments are trivia, skip them unless asked not to
+		if tok.Type == token.COMMENT && !l.emitComments {
+			continue
+		}
+
+		return tok
+	}
+}
+
+// readToken reads the token starting at the current character
+func (l *Lexer) readToken() token.Token {
+	var tok token.Token
 
 	switch l.ch {
 	case '=':
@@ -51,7 +115,7 @@ func (l *Lexer) NextToken() token.Token {
 			// it's the EQ operator
 
 			// we can't use the newToken helper function
-			// as it takes in a byte, not an array of bytes
+			// as it takes in a single char
 			tok = token.Token{
 				Type:    token.EQ,
 				Literal: string(l.ch) + string(nextChar),
@@ -63,9 +127,60 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.ASSIGN, l.ch)
 		}
 	case '+':
-		tok = newToken(token.PLUS, l.ch)
+		// handle the "+=" and "++" operators, by peeking the character after
+		switch l.peekChar() {
+		case '=':
+			tok = token.Token{
+				Type:    token.PLUS_ASSIGN,
+				Literal: "+=",
+			}
+
+			// call readchar to skip the "="
+			l.readChar()
+		case '+':
+			// skip the first "+"
+			l.readChar()
+
+			// a ++ that's directly followed by an operand is two pluses
+			if startsOperand(l.peekChar()) {
+				return newToken(token.PLUS, '+')
+			}
+
+			tok = token.Token{
+				Type:    token.INCREMENT,
+				Literal: "++",
+			}
+		default:
+			tok = newToken(token.PLUS, l.ch)
+		}
 	case '-':
-		tok = newToken(token.MINUS, l.ch)
+		// handle the "-=" and "--" operators, by peeking the character after
+		switch l.peekChar() {
+		case '=':
+			tok = token.Token{
+				Type:    token.MINUS_ASSIGN,
+				Literal: "-=",
+			}
+
+			// call readchar to skip the "="
+			l.readChar()
+		case '-':
+			// skip the first "-"
+			l.readChar()
+
+			// a -- that's directly followed by an operand is two minuses,
+			// so a--b is still a - -b and --5 is still -(-5)
+			if startsOperand(l.peekChar()) {
+				return newToken(token.MINUS, '-')
+			}
+
+			tok = token.Token{
+				Type:    token.DECREMENT,
+				Literal: "--",
+			}
+		default:
+			tok = newToken(token.MINUS, l.ch)
+		}
 	case '!':
 		// handle the "!=" operator, by peeking the character after
 		nextChar := l.peekChar()
@@ -75,7 +190,7 @@ func (l *Lexer) NextToken() token.Token {
 			// it's the NEQ operator
 
 			// we can't use the newToken helper function
-			// as it takes in a byte, not an array of bytes
+			// as it takes in a single char
 			tok = token.Token{
 				Type:    token.NEQ,
 				Literal: string(l.ch) + string(nextChar),
@@ -87,17 +202,141 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.BANG, l.ch)
 		}
 	case '/':
-		tok = newToken(token.SLASH, l.ch)
+		// handle comments, by peeking the character after
+		// and return early on to prevent calling l.readChar() again
+		switch l.peekChar() {
+		case '/':
+			return l.readLineComment()
+		case '*':
+			return l.readBlockComment()
+		case '=':
+			tok = token.Token{
+				Type:    token.SLASH_ASSIGN,
+				Literal: "/=",
+			}
+
+			// call readchar to skip the "="
+			l.readChar()
+		default:
+			tok = newToken(token.SLASH, l.ch)
+		}
 	case '*':
-		tok = newToken(token.ASTERISK, l.ch)
+		// handle the "**" and "*=" operators, by peeking the character after
+		switch l.peekChar() {
+		case '*':
+			tok = token.Token{
+				Type:    token.POWER,
+				Literal: "**",
+			}
+
+			// call readchar to skip the second "*"
+			l.readChar()
+		case '=':
+			tok = token.Token{
+				Type:    token.ASTERISK_ASSIGN,
+				Literal: "*=",
+			}
+
+			// call readchar to skip the "="
+			l.readChar()
+		default:
+			tok = newToken(token.ASTERISK, l.ch)
+		}
+	case '%':
+		tok = newToken(token.PERCENT, l.ch)
+	case '&':
+		// handle the "&&" operator, by peeking the character after
+		if l.peekChar() == '&' {
+			tok = token.Token{
+				Type:    token.AND,
+				Literal: "&&",
+			}
+
+			// call readchar to skip the second "&"
+			l.readChar()
+		} else {
+			tok = newToken(token.BIT_AND, l.ch)
+		}
+	case '|':
+		// handle the "||" operator, by peeking the character after
+		if l.peekChar() == '|' {
+			tok = token.Token{
+				Type:    token.OR,
+				Literal: "||",
+			}
+
+			// call readchar to skip the second "|"
+			l.readChar()
+		} else {
+			tok = newToken(token.BIT_OR, l.ch)
+		}
+	case '^':
+		tok = newToken(token.BIT_XOR, l.ch)
+	case '~':
+		tok = newToken(token.BIT_NOT, l.ch)
 	case '<':
-		tok = newToken(token.LT, l.ch)
+		// handle the "<=" and "<<" operators, by peeking the character after
+		switch l.peekChar() {
+		case '=':
+			tok = token.Token{
+				Type:    token.LTE,
+				Literal: "<=",
+			}
+
+			// call readchar to skip the "="
+			l.readChar()
+		case '<':
+			tok = token.Token{
+				Type:    token.SHL,
+				Literal: "<<",
+			}
+
+			// call readchar to skip the second "<"
+			l.readChar()
+		default:
+			tok = newToken(token.LT, l.ch)
+		}
 	case '>':
-		tok = newToken(token.GT, l.ch)
+		// handle the ">=" and ">>" operators, by peeking the character after
+		switch l.peekChar() {
+		case '=':
+			tok = token.Token{
+				Type:    token.GTE,
+				Literal: ">=",
+			}
+
+			// call readchar to skip the "="
+			l.readChar()
+		case '>':
+			tok = token.Token{
+				Type:    token.SHR,
+				Literal: ">>",
+			}
+
+			// call readchar to skip the second ">"
+			l.readChar()
+		default:
+			tok = newToken(token.GT, l.ch)
+		}
+	case '?':
+		// handle the "??" operator, by peeking the character after
+		if l.peekChar() == '?' {
+			tok = token.Token{
+				Type:    token.COALESCE,
+				Literal: "??",
+			}
+
+			// call readchar to skip the second "?"
+			l.readChar()
+		} else {
+			tok = newToken(token.QUESTION, l.ch)
+		}
 	case ';':
 		tok = newToken(token.SEMICOLON, l.ch)
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
+	case ':':
+		tok = newToken(token.COLON, l.ch)
 	case '(':
 		tok = newToken(token.LPAREN, l.ch)
 	case ')':
@@ -106,10 +345,30 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
 		tok = newToken(token.RBRACE, l.ch)
+	case '[':
+		tok = newToken(token.LBRACKET, l.ch)
+	case ']':
+		tok = newToken(token.RBRACKET, l.ch)
+	case '"':
+		// return early on, readString already skips the closing `"`
+		return l.readString()
 	case 0:
 		tok.Literal = ""
 		tok.Type = token.EOF
+
+		// return early on to stay at the end of the input
+		return tok
 	default:
+		// invalid UTF-8 is decoded as a 1 byte wide utf8.RuneError
+		if l.ch == utf8.RuneError && l.width == 1 {
+			tok = token.Token{
+				Type:    token.ILLEGAL,
+				Literal: l.raw,
+			}
+
+			break
+		}
+
 		// handle identifiers (variable names) and keywords
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
@@ -119,13 +378,10 @@ func (l *Lexer) NextToken() token.Token {
 			return tok
 		}
 
-		// handle numbers (integers)
+		// handle numbers (integers and floats)
 		if isDigit(l.ch) {
-			tok.Type = token.INT
-			tok.Literal = l.readNumber()
-
 			// return early on to prevent calling l.readChar() again
-			return tok
+			return l.readNumber()
 		}
 
 		// anything else is illegal and unknown to our parser
@@ -137,62 +393,62 @@ func (l *Lexer) NextToken() token.Token {
 }
 
 // readChar is a helper function that advances
-// through our input by incrementing pos and nextPos by 1
-// (and updating our current character)
+// through our input by moving onto the next UTF-8 character
+// (and decoding the one after it)
 func (l *Lexer) readChar() {
-	// Peek into the next position and check len to prevent out of bounds
-	if l.nextPos >= len(l.input) {
-		// 0 is NUL in ASCII
-		l.ch = 0
-	} else {
-		// Otherwise, set the char to the next char
-		l.ch = l.input[l.nextPos]
+	// keep track of the line and column we're at
+	if l.ch == '\n' {
+		l.line++
+		l.col = 0
 	}
+	l.col++
+
+	// Update the byte offset
+	l.pos += l.width
 
-	// Update the "pointers"
-	l.pos = l.nextPos
-	l.nextPos++
+	// 0 is NUL in ASCII, the next char is empty at the end of the input
+	l.ch, l.width, l.raw = l.next.r, l.next.width, l.next.raw
+
+	// Don't read past the end of the input
+	if l.width > 0 {
+		l.next = l.decodeChar()
+	}
 }
 
 // readIdentifier starts reading up from the current position
 // until any character that is NOT a letter (for example space)
 func (l *Lexer) readIdentifier() string {
-	pos := l.pos
+	var out strings.Builder
 
 	// keep advancing through our input
 	// and check if ch is still a letter
 	for isLetter(l.ch) {
+		out.WriteRune(l.ch)
 		l.readChar()
 	}
 
 	// return the identifier
-	return l.input[pos:l.pos]
+	return out.String()
 }
 
-// readIdentifier starts reading up from the current position
-// until any character that is NOT a digit
-// TODO: handle floats, non-decimal notation, etc
-func (l *Lexer) readNumber() string {
-	pos := l.pos
-
-	// keep advancing through our input
-	// and check if ch is still a digit
-	for isDigit(l.ch) {
-		l.readChar()
-	}
-
-	return l.input[pos:l.pos]
+// isLetter checks if the given character is a Unicode letter or _
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_'
 }
 
-// isDigit checks if the given character matches [0-9]
-func isDigit(ch byte) bool {
-	return '0' <= ch && ch <= '9'
+// startsOperand checks if the given character can start an operand
+// (an identifier, a number, a string or a grouped expression)
+func startsOperand(ch rune) bool {
+	return isLetter(ch) || isDigit(ch) || ch == '"' || ch == '('
 }
 
-// isLetter checks if the given character matches [a-zA-Z_]
-func isLetter(ch byte) bool {
-	// TODO: maybe support for UTF?
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+// position returns the position of the current character
+func (l *Lexer) position() token.Position {
+	return token.Position{
+		Line:   l.line,
+		Column: l.col,
+		Offset: l.pos,
+	}
 }
 
 // eatWhitespace is a helper function that keeps advancing
@@ -205,16 +461,12 @@ func (l *Lexer) eatWhitespace() {
 
 // peekChar is a helper function that returns the character
 // after the current position (or NUL)
-func (l *Lexer) peekChar() byte {
-	if l.nextPos >= len(l.input) {
-		return 0
-	}
-
-	return l.input[l.nextPos]
+func (l *Lexer) peekChar() rune {
+	return l.next.r
 }
 
 // newToken is a helper function that simplifies creating tokens
-func newToken(tokenType token.Type, ch byte) token.Token {
+func newToken(tokenType token.Type, ch rune) token.Token {
 	return token.Token{
 		Type:    tokenType,
 		Literal: string(ch),
@@ -1,7 +1,11 @@
 package lexer
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/fr3fou/monkey/token"
 )
@@ -15,7 +19,7 @@ let add = fun(x, y) {
 };
 
 let result = add(five, ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 
 if (5 < 10) {
@@ -26,6 +30,15 @@ if (5 < 10) {
 
 10 == 10;
 10 != 9;
+[1, 2];
+{"foo": "bar"}
+a && b || c & d | e
+a <= b >= c % d ** e * f
+~a ^ b << c >> d < e > f
+while for in break continue
+x += 1 -= 2 *= 3 /= 4 = 5
+x++ + y-- - f()?
+a ?? b ? c : d
 `
 
 	tests := []struct {
@@ -105,10 +118,391 @@ if (5 < 10) {
 		{token.NEQ, "!="},
 		{token.INT, "9"},
 		{token.SEMICOLON, ";"},
+		{token.LBRACKET, "["},
+		{token.INT, "1"},
+		{token.COMMA, ","},
+		{token.INT, "2"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.LBRACE, "{"},
+		{token.STRING, "foo"},
+		{token.COLON, ":"},
+		{token.STRING, "bar"},
+		{token.RBRACE, "}"},
+		{token.IDENT, "a"},
+		{token.AND, "&&"},
+		{token.IDENT, "b"},
+		{token.OR, "||"},
+		{token.IDENT, "c"},
+		{token.BIT_AND, "&"},
+		{token.IDENT, "d"},
+		{token.BIT_OR, "|"},
+		{token.IDENT, "e"},
+		{token.IDENT, "a"},
+		{token.LTE, "<="},
+		{token.IDENT, "b"},
+		{token.GTE, ">="},
+		{token.IDENT, "c"},
+		{token.PERCENT, "%"},
+		{token.IDENT, "d"},
+		{token.POWER, "**"},
+		{token.IDENT, "e"},
+		{token.ASTERISK, "*"},
+		{token.IDENT, "f"},
+		{token.BIT_NOT, "~"},
+		{token.IDENT, "a"},
+		{token.BIT_XOR, "^"},
+		{token.IDENT, "b"},
+		{token.SHL, "<<"},
+		{token.IDENT, "c"},
+		{token.SHR, ">>"},
+		{token.IDENT, "d"},
+		{token.LT, "<"},
+		{token.IDENT, "e"},
+		{token.GT, ">"},
+		{token.IDENT, "f"},
+		{token.WHILE, "while"},
+		{token.FOR, "for"},
+		{token.IN, "in"},
+		{token.BREAK, "break"},
+		{token.CONTINUE, "continue"},
+		{token.IDENT, "x"},
+		{token.PLUS_ASSIGN, "+="},
+		{token.INT, "1"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.INT, "2"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.INT, "3"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "4"},
+		{token.ASSIGN, "="},
+		{token.INT, "5"},
+		{token.IDENT, "x"},
+		{token.INCREMENT, "++"},
+		{token.PLUS, "+"},
+		{token.IDENT, "y"},
+		{token.DECREMENT, "--"},
+		{token.MINUS, "-"},
+		{token.IDENT, "f"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.QUESTION, "?"},
+		{token.IDENT, "a"},
+		{token.COALESCE, "??"},
+		{token.IDENT, "b"},
+		{token.QUESTION, "?"},
+		{token.IDENT, "c"},
+		{token.COLON, ":"},
+		{token.IDENT, "d"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+
+}
+
+func TestNextTokenPositions(t *testing.T) {
+	input := `let x = 5;
+if (x != 10) {
+	x
+}`
+
+	tests := []struct {
+		expectedType token.Type
+		expectedPos  token.Position
+		expectedEnd  token.Position
+	}{
+		{token.LET, token.Position{Line: 1, Column: 1, Offset: 0}, token.Position{Line: 1, Column: 4, Offset: 3}},
+		{token.IDENT, token.Position{Line: 1, Column: 5, Offset: 4}, token.Position{Line: 1, Column: 6, Offset: 5}},
+		{token.ASSIGN, token.Position{Line: 1, Column: 7, Offset: 6}, token.Position{Line: 1, Column: 8, Offset: 7}},
+		{token.INT, token.Position{Line: 1, Column: 9, Offset: 8}, token.Position{Line: 1, Column: 10, Offset: 9}},
+		{token.SEMICOLON, token.Position{Line: 1, Column: 10, Offset: 9}, token.Position{Line: 1, Column: 11, Offset: 10}},
+		{token.IF, token.Position{Line: 2, Column: 1, Offset: 11}, token.Position{Line: 2, Column: 3, Offset: 13}},
+		{token.LPAREN, token.Position{Line: 2, Column: 4, Offset: 14}, token.Position{Line: 2, Column: 5, Offset: 15}},
+		{token.IDENT, token.Position{Line: 2, Column: 5, Offset: 15}, token.Position{Line: 2, Column: 6, Offset: 16}},
+		{token.NEQ, token.Position{Line: 2, Column: 7, Offset: 17}, token.Position{Line: 2, Column: 9, Offset: 19}},
+		{token.INT, token.Position{Line: 2, Column: 10, Offset: 20}, token.Position{Line: 2, Column: 12, Offset: 22}},
+		{token.RPAREN, token.Position{Line: 2, Column: 12, Offset: 22}, token.Position{Line: 2, Column: 13, Offset: 23}},
+		{token.LBRACE, token.Position{Line: 2, Column: 14, Offset: 24}, token.Position{Line: 2, Column: 15, Offset: 25}},
+		{token.IDENT, token.Position{Line: 3, Column: 2, Offset: 27}, token.Position{Line: 3, Column: 3, Offset: 28}},
+		{token.RBRACE, token.Position{Line: 4, Column: 1, Offset: 29}, token.Position{Line: 4, Column: 2, Offset: 30}},
+		{token.EOF, token.Position{Line: 4, Column: 2, Offset: 30}, token.Position{Line: 4, Column: 2, Offset: 30}},
+		{token.EOF, token.Position{Line: 4, Column: 2, Offset: 30}, token.Position{Line: 4, Column: 2, Offset: 30}},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Pos != tt.expectedPos {
+			t.Fatalf("tests[%d] - pos wrong. expected=%+v, got=%+v",
+				i, tt.expectedPos, tok.Pos)
+		}
+
+		if tok.End != tt.expectedEnd {
+			t.Fatalf("tests[%d] - end wrong. expected=%+v, got=%+v",
+				i, tt.expectedEnd, tok.End)
+		}
+	}
+}
+
+func TestNextTokenUnicode(t *testing.T) {
+	input := "let число = 5;\nlet π_2 = число + ünïcödé;\n\xff 日本"
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+		expectedPos     token.Position
+	}{
+		{token.LET, "let", token.Position{Line: 1, Column: 1, Offset: 0}},
+		{token.IDENT, "число", token.Position{Line: 1, Column: 5, Offset: 4}},
+		{token.ASSIGN, "=", token.Position{Line: 1, Column: 11, Offset: 15}},
+		{token.INT, "5", token.Position{Line: 1, Column: 13, Offset: 17}},
+		{token.SEMICOLON, ";", token.Position{Line: 1, Column: 14, Offset: 18}},
+		{token.LET, "let", token.Position{Line: 2, Column: 1, Offset: 20}},
+		{token.IDENT, "π_", token.Position{Line: 2, Column: 5, Offset: 24}},
+		{token.INT, "2", token.Position{Line: 2, Column: 7, Offset: 27}},
+		{token.ASSIGN, "=", token.Position{Line: 2, Column: 9, Offset: 29}},
+		{token.IDENT, "число", token.Position{Line: 2, Column: 11, Offset: 31}},
+		{token.PLUS, "+", token.Position{Line: 2, Column: 17, Offset: 42}},
+		{token.IDENT, "ünïcödé", token.Position{Line: 2, Column: 19, Offset: 44}},
+		{token.SEMICOLON, ";", token.Position{Line: 2, Column: 26, Offset: 55}},
+		{token.ILLEGAL, "\xff", token.Position{Line: 3, Column: 1, Offset: 57}},
+		{token.IDENT, "日本", token.Position{Line: 3, Column: 3, Offset: 59}},
+		{token.EOF, "", token.Position{Line: 3, Column: 5, Offset: 65}},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Pos != tt.expectedPos {
+			t.Fatalf("tests[%d] - pos wrong. expected=%+v, got=%+v",
+				i, tt.expectedPos, tok.Pos)
+		}
+	}
+}
+
+func TestNewReaderMatchesNew(t *testing.T) {
+	input := "let число = fun(x, y) {\n\tx + y;\n};\nif (5 < 10) { return true; } else { return !false; }\n10 == 10; 10 != 9; \xff"
+
+	expected := New(input)
+	l := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+
+	for i := 0; ; i++ {
+		want := expected.NextToken()
+		got := l.NextToken()
+
+		if got != want {
+			t.Fatalf("tokens[%d] - wrong token. expected=%+v, got=%+v",
+				i, want, got)
+		}
+
+		if want.Type == token.EOF {
+			break
+		}
+	}
+
+	if l.Err() != nil {
+		t.Fatalf("unexpected reader error: %v", l.Err())
+	}
+}
+
+func TestNewReaderLargeInput(t *testing.T) {
+	statement := "let x = x + 1;\n"
+	count := 100000
+
+	// stream the statements without ever holding the whole input in memory
+	pr, pw := io.Pipe()
+	go func() {
+		for i := 0; i < count; i++ {
+			io.WriteString(pw, statement)
+		}
+		pw.Close()
+	}()
+
+	l := NewReader(pr)
+
+	tokens := 0
+	var last token.Token
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		if tok.Type == token.ILLEGAL {
+			t.Fatalf("unexpected illegal token %+v", tok)
+		}
+
+		tokens++
+		last = tok
+	}
+
+	if tokens != count*7 {
+		t.Fatalf("wrong number of tokens. expected=%d, got=%d", count*7, tokens)
+	}
+
+	expectedPos := token.Position{Line: count, Column: 14, Offset: len(statement)*count - 2}
+	if last.Pos != expectedPos {
+		t.Fatalf("last token pos wrong. expected=%+v, got=%+v", expectedPos, last.Pos)
+	}
+}
+
+func TestNewReaderError(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("let x"), iotest.ErrReader(errRead))
+
+	l := NewReader(r)
+
+	expected := []token.Type{token.LET, token.IDENT, token.EOF, token.EOF}
+	for i, tt := range expected {
+		tok := l.NextToken()
+
+		if tok.Type != tt {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt, tok.Type)
+		}
+	}
+
+	if l.Err() != errRead {
+		t.Fatalf("wrong reader error. expected=%v, got=%v", errRead, l.Err())
+	}
+}
+
+func TestNextTokenStrings(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{`"foobar"`, token.STRING, "foobar"},
+		{`"foo bar"`, token.STRING, "foo bar"},
+		{`""`, token.STRING, ""},
+		{`"здравей, свят"`, token.STRING, "здравей, свят"},
+		{`"tab\there\nnewline"`, token.STRING, "tab\there\nnewline"},
+		{`"say \"hi\""`, token.STRING, `say "hi"`},
+		{`"back\\slash"`, token.STRING, `back\slash`},
+		{`"\u{41}\u{416}\u{1F412}"`, token.STRING, "AЖ🐒"},
+		{"\"multi\nline\"", token.STRING, "multi\nline"},
+		{`"unterminated`, token.ILLEGAL, `"unterminated`},
+		{`"unterminated\`, token.ILLEGAL, `"unterminated\`},
+		{`"bad \q escape"`, token.ILLEGAL, `"bad \q escape"`},
+		{`"\u41"`, token.ILLEGAL, `"\u41"`},
+		{`"\u{}"`, token.ILLEGAL, `"\u{}"`},
+		{`"\u{110000}"`, token.ILLEGAL, `"\u{110000}"`},
+		{`"\u{D800}"`, token.ILLEGAL, `"\u{D800}"`},
+		{"\"bad \xff utf8\"", token.ILLEGAL, "\"bad \xff utf8\""},
+	}
+
+	for i, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Fatalf("tests[%d] - expected EOF after the string. got=%+v", i, next)
+		}
+	}
+}
+
+func TestNextTokenIncrementDecrement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []token.Type
+	}{
+		{"x++", []token.Type{token.IDENT, token.INCREMENT}},
+		{"x-- - 1", []token.Type{token.IDENT, token.DECREMENT, token.MINUS, token.INT}},
+		{"x---1", []token.Type{token.IDENT, token.DECREMENT, token.MINUS, token.INT}},
+		{"a--b", []token.Type{token.IDENT, token.MINUS, token.MINUS, token.IDENT}},
+		{"--5", []token.Type{token.MINUS, token.MINUS, token.INT}},
+		{"5--3", []token.Type{token.INT, token.MINUS, token.MINUS, token.INT}},
+		{"--(x)", []token.Type{token.MINUS, token.MINUS, token.LPAREN, token.IDENT, token.RPAREN}},
+		{"a++b", []token.Type{token.IDENT, token.PLUS, token.PLUS, token.IDENT}},
+		{`a++"b"`, []token.Type{token.IDENT, token.PLUS, token.PLUS, token.STRING}},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+
+		for i, expected := range append(tt.expected, token.EOF) {
+			tok := l.NextToken()
+
+			if tok.Type != expected {
+				t.Errorf("%q[%d] - tokentype wrong. expected=%q, got=%q",
+					tt.input, i, expected, tok.Type)
+				break
+			}
+		}
+	}
+}
+
+func TestNextTokenComments(t *testing.T) {
+	input := `// leading comment
+let x = 10 / 2; // trailing comment
+/* block
+   comment */ x
+/* outer /* nested */ still outer */ x /**/ /* unterminated /* */`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.COMMENT, "// leading comment"},
+		{token.LET, "let"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "10"},
+		{token.SLASH, "/"},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.COMMENT, "// trailing comment"},
+		{token.COMMENT, "/* block\n   comment */"},
+		{token.IDENT, "x"},
+		{token.COMMENT, "/* outer /* nested */ still outer */"},
+		{token.IDENT, "x"},
+		{token.COMMENT, "/**/"},
+		{token.ILLEGAL, "/* unterminated /* */"},
 		{token.EOF, ""},
 	}
 
 	l := New(input)
+	l.EmitComments(true)
 
 	for i, tt := range tests {
 		tok := l.NextToken()
@@ -124,4 +518,64 @@ if (5 < 10) {
 		}
 	}
 
+	// without emitting comments, they're skipped like whitespace
+	l = New(input)
+
+	for _, tt := range tests {
+		if tt.expectedType == token.COMMENT {
+			continue
+		}
+
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tokentype wrong. expected=%q, got=%q", tt.expectedType, tok.Type)
+		}
+	}
+}
+
+func TestNextTokenNumbers(t *testing.T) {
+	input := `5 3.14 0.5 10.0 1e9 2.5E-3 6e+2 1.x 7e 8.5e- 1_000 0xFF_ff 0o17 0b101 0xzz9 0b; 1_000.000_5`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.INT, "5"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "0.5"},
+		{token.FLOAT, "10.0"},
+		{token.FLOAT, "1e9"},
+		{token.FLOAT, "2.5E-3"},
+		{token.FLOAT, "6e+2"},
+		{token.INT, "1"},
+		{token.ILLEGAL, "."},
+		{token.IDENT, "x"},
+		{token.ILLEGAL, "7e"},
+		{token.ILLEGAL, "8.5e-"},
+		{token.INT, "1_000"},
+		{token.INT, "0xFF_ff"},
+		{token.INT, "0o17"},
+		{token.INT, "0b101"},
+		{token.INT, "0xzz9"},
+		{token.INT, "0b"},
+		{token.SEMICOLON, ";"},
+		{token.FLOAT, "1_000.000_5"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
 }
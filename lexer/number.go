@@ -0,0 +1,96 @@
+package lexer
+
+import (
+	"strings"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// readNumber starts reading up from the current position
+// until the end of the integer or float literal
+// 5, 1_000_000
+// 0xff, 0o755, 0b1010
+// 3.14
+// 1e9, 2.5E-3
+//
+// Digits aren't validated here, the parser reports any malformed literals
+func (l *Lexer) readNumber() token.Token {
+	var out strings.Builder
+	tokType := token.Type(token.INT)
+
+	// handle non-decimal notation
+	if l.ch == '0' && isBasePrefix(l.peekChar()) {
+		out.WriteRune(l.ch)
+		l.readChar()
+		out.WriteRune(l.ch)
+		l.readChar()
+
+		// read anything alphanumeric, so that invalid digits (0xZZ)
+		// don't get split into separate tokens
+		for isLetter(l.ch) || isDigit(l.ch) {
+			out.WriteRune(l.ch)
+			l.readChar()
+		}
+
+		return token.Token{Type: tokType, Literal: out.String()}
+	}
+
+	l.readDigits(&out)
+
+	// only treat the `.` as a part of the number if it has digits after it
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		tokType = token.FLOAT
+
+		out.WriteRune(l.ch)
+		l.readChar()
+
+		l.readDigits(&out)
+	}
+
+	// handle the exponent
+	if l.ch == 'e' || l.ch == 'E' {
+		tokType = token.FLOAT
+
+		out.WriteRune(l.ch)
+		l.readChar()
+
+		if l.ch == '+' || l.ch == '-' {
+			out.WriteRune(l.ch)
+			l.readChar()
+		}
+
+		// the exponent can't be empty
+		if !isDigit(l.ch) {
+			return token.Token{Type: token.ILLEGAL, Literal: out.String()}
+		}
+
+		l.readDigits(&out)
+	}
+
+	return token.Token{Type: tokType, Literal: out.String()}
+}
+
+// readDigits keeps advancing through our input
+// while ch is still a digit (or a `_` separator)
+func (l *Lexer) readDigits(out *strings.Builder) {
+	for isDigit(l.ch) || l.ch == '_' {
+		out.WriteRune(l.ch)
+		l.readChar()
+	}
+}
+
+// isBasePrefix checks if the given character is one of
+// the characters that come after the 0 in 0x, 0o and 0b
+func isBasePrefix(ch rune) bool {
+	switch ch {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	default:
+		return false
+	}
+}
+
+// isDigit checks if the given character matches [0-9]
+func isDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9'
+}
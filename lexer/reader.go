@@ -0,0 +1,56 @@
+package lexer
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// bufferSize is the amount of bytes the lexer buffers from its reader,
+// only the current token has to fit in memory, not the whole input
+const bufferSize = 4096
+
+// char is a single decoded character of the input
+type char struct {
+	r rune
+
+	// width is the amount of bytes the char takes up (0 at the end of the input)
+	width int
+
+	// raw holds the original byte when the input isn't valid UTF-8
+	raw string
+}
+
+// newReader wraps the reader with a bounded buffer,
+// readers that are already buffered enough are reused
+func newReader(r io.Reader) *bufio.Reader {
+	return bufio.NewReaderSize(r, bufferSize)
+}
+
+// decodeChar reads the next UTF-8 character from the reader,
+// it returns an empty char at the end of the input
+func (l *Lexer) decodeChar() char {
+	r, width, err := l.r.ReadRune()
+
+	if err != nil {
+		// keep the first error around, so the caller can tell
+		// a failed read apart from the actual end of the input
+		if err != io.EOF && l.err == nil {
+			l.err = err
+		}
+
+		return char{}
+	}
+
+	c := char{r: r, width: width}
+
+	// invalid UTF-8 is decoded as a 1 byte wide utf8.RuneError,
+	// go back and grab the original byte
+	if r == utf8.RuneError && width == 1 {
+		l.r.UnreadRune()
+		b, _ := l.r.ReadByte()
+		c.raw = string([]byte{b})
+	}
+
+	return c
+}
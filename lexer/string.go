@@ -0,0 +1,151 @@
+package lexer
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// readString reads a double quoted string literal starting
+// at the current `"` and decodes all of the escape sequences in it
+// "foo\tbar\n"
+// "\u{1F412}"
+//
+// Unterminated strings, unknown escape sequences and
+// invalid UTF-8 make the whole literal ILLEGAL
+func (l *Lexer) readString() token.Token {
+	// raw keeps the source text, so we can report ILLEGAL literals as is
+	var raw, out strings.Builder
+	illegal := false
+
+	raw.WriteRune(l.ch)
+	l.readChar()
+
+	for l.ch != '"' {
+		// reached the end of the input without a closing `"`
+		if l.width == 0 {
+			return token.Token{Type: token.ILLEGAL, Literal: raw.String()}
+		}
+
+		if l.ch == utf8.RuneError && l.width == 1 {
+			raw.WriteString(l.raw)
+			illegal = true
+			l.readChar()
+			continue
+		}
+
+		raw.WriteRune(l.ch)
+
+		if l.ch != '\\' {
+			out.WriteRune(l.ch)
+			l.readChar()
+			continue
+		}
+
+		// handle escape sequences
+		l.readChar()
+
+		// let the loop report the missing closing `"`
+		if l.width == 0 {
+			continue
+		}
+
+		raw.WriteRune(l.ch)
+
+		switch l.ch {
+		case 'n':
+			out.WriteRune('\n')
+		case 't':
+			out.WriteRune('\t')
+		case '"':
+			out.WriteRune('"')
+		case '\\':
+			out.WriteRune('\\')
+		case 'u':
+			ch, ok := l.readUnicodeEscape(&raw)
+			if !ok {
+				illegal = true
+				continue
+			}
+
+			out.WriteRune(ch)
+		default:
+			illegal = true
+		}
+
+		l.readChar()
+	}
+
+	raw.WriteRune(l.ch)
+
+	// skip the closing `"`
+	l.readChar()
+
+	if illegal {
+		return token.Token{Type: token.ILLEGAL, Literal: raw.String()}
+	}
+
+	return token.Token{Type: token.STRING, Literal: out.String()}
+}
+
+// readUnicodeEscape reads the `{...}` part of an \u{...} escape sequence
+// and reports whether the code point is valid,
+// valid escapes stop at the closing `}`
+func (l *Lexer) readUnicodeEscape(raw *strings.Builder) (rune, bool) {
+	l.readChar()
+
+	if l.ch != '{' {
+		return 0, false
+	}
+
+	raw.WriteRune(l.ch)
+	l.readChar()
+
+	var ch rune
+	digits := 0
+
+	for isHexDigit(l.ch) {
+		ch = ch<<4 | hexValue(l.ch)
+		digits++
+
+		raw.WriteRune(l.ch)
+		l.readChar()
+
+		// anything longer than 6 digits is out of the Unicode range anyways
+		if digits > 6 {
+			return 0, false
+		}
+	}
+
+	if l.ch != '}' {
+		return 0, false
+	}
+
+	raw.WriteRune(l.ch)
+
+	if digits == 0 || !utf8.ValidRune(ch) {
+		// skip the `}` as well, the escape sequence is over
+		l.readChar()
+		return 0, false
+	}
+
+	return ch, true
+}
+
+// isHexDigit checks if the given character matches [0-9a-fA-F]
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+// hexValue returns the numeric value of a hex digit
+func hexValue(ch rune) rune {
+	switch {
+	case isDigit(ch):
+		return ch - '0'
+	case 'a' <= ch && ch <= 'f':
+		return ch - 'a' + 10
+	default:
+		return ch - 'A' + 10
+	}
+}
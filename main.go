@@ -2,13 +2,38 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 
+	"github.com/fr3fou/monkey/evaluator"
+	"github.com/fr3fou/monkey/lexer"
+	"github.com/fr3fou/monkey/object"
+	"github.com/fr3fou/monkey/parser"
 	"github.com/fr3fou/monkey/repl"
 )
 
 func main() {
+	// monkey script.mk
+	if len(os.Args) > 1 {
+		f, err := os.Open(os.Args[1])
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		code := run(f)
+		f.Close()
+
+		os.Exit(code)
+	}
+
+	// cat script.mk | monkey
+	if !isTerminal(os.Stdin) {
+		os.Exit(run(os.Stdin))
+	}
+
 	user, err := user.Current()
 
 	if err != nil {
@@ -20,3 +45,45 @@ func main() {
 
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+// run lexes, parses and evaluates the whole program from r
+// and returns the exit code
+func run(r io.Reader) int {
+	l := lexer.NewReader(r)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+
+	if l.Err() != nil {
+		fmt.Fprintln(os.Stderr, l.Err())
+		return 1
+	}
+
+	if len(p.Errors()) != 0 {
+		for _, err := range p.Errors() {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		return 1
+	}
+
+	evaluated := evaluator.Eval(program, object.NewEnvironment())
+
+	if evaluated != nil && evaluated.Type() == object.ERROR_OBJ {
+		fmt.Fprintln(os.Stderr, evaluated.Inspect())
+		return 1
+	}
+
+	return 0
+}
+
+// isTerminal reports whether the file is an interactive terminal
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,32 @@
+package object
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Array represents an ordered list of values
+type Array struct {
+	Elements []Object
+}
+
+// Inspect is used for debugging
+func (a *Array) Inspect() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, e := range a.Elements {
+		elements = append(elements, e.Inspect())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// Type returns the array type
+func (a *Array) Type() Type {
+	return ARRAY_OBJ
+}
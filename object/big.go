@@ -0,0 +1,19 @@
+package object
+
+import "math/big"
+
+// BigInteger represents an integer value that doesn't fit in 64 bits,
+// integer arithmetic that overflows is automatically promoted to it
+type BigInteger struct {
+	Value *big.Int
+}
+
+// Inspect is used for debugging
+func (bi *BigInteger) Inspect() string {
+	return bi.Value.String()
+}
+
+// Type returns the big integer type
+func (bi *BigInteger) Type() Type {
+	return BIG_INTEGER_OBJ
+}
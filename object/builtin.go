@@ -0,0 +1,20 @@
+package object
+
+// BuiltinFunction is the signature of functions implemented in Go
+type BuiltinFunction func(args ...Object) Object
+
+// Builtin represents a function implemented in Go
+type Builtin struct {
+	Name string
+	Fn   BuiltinFunction
+}
+
+// Inspect is used for debugging
+func (b *Builtin) Inspect() string {
+	return "builtin function " + b.Name
+}
+
+// Type returns the builtin type
+func (b *Builtin) Type() Type {
+	return BUILTIN_OBJ
+}
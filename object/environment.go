@@ -0,0 +1,60 @@
+package object
+
+// Environment is a scope that keeps track of all the bound names
+// and their values. Environments can be nested (e.g. function bodies),
+// in which case names that are missing are looked up in the outer one
+type Environment struct {
+	store map[string]Object
+	outer *Environment
+}
+
+// NewEnvironment returns a pointer to an empty top level Environment
+func NewEnvironment() *Environment {
+	return &Environment{
+		store: make(map[string]Object),
+	}
+}
+
+// NewEnclosedEnvironment returns a pointer to an empty Environment
+// that falls back to the provided outer one on lookups
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+
+	return env
+}
+
+// Get returns the value bound to the given name,
+// walking up the outer scopes if needed
+func (e *Environment) Get(name string) (Object, bool) {
+	obj, ok := e.store[name]
+
+	// if the name isn't bound here, try the outer scope
+	if !ok && e.outer != nil {
+		return e.outer.Get(name)
+	}
+
+	return obj, ok
+}
+
+// Set binds the value to the given name in the current scope,
+// shadowing any binding with the same name in the outer scopes
+func (e *Environment) Set(name string, val Object) Object {
+	e.store[name] = val
+	return val
+}
+
+// Assign updates the value of a name that's already bound,
+// in the closest scope that it's bound in, and reports whether it was found
+func (e *Environment) Assign(name string, val Object) bool {
+	if _, ok := e.store[name]; ok {
+		e.store[name] = val
+		return true
+	}
+
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+
+	return false
+}
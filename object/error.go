@@ -0,0 +1,65 @@
+package object
+
+import (
+	"strings"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// Error represents a runtime error, once it's produced
+// it aborts the evaluation of the whole program
+type Error struct {
+	Message string
+
+	// Operator and Operands describe the offending operation (if any)
+	Operator string
+	Operands []Object
+
+	// Pos is the position of the expression that caused the error
+	Pos token.Position
+
+	// Stack holds the function calls the error bubbled up through,
+	// starting from the innermost one
+	Stack []Frame
+}
+
+// Frame is a single call of a function in the stack trace of an error
+type Frame struct {
+	// Function is the name of the called function, empty for anonymous ones
+	Function string
+
+	// Pos is the position of the call
+	Pos token.Position
+}
+
+// String formats the frame - at add (called from 3:4)
+func (f Frame) String() string {
+	name := f.Function
+	if name == "" {
+		name = "<anonymous>"
+	}
+
+	return "at " + name + " (called from " + f.Pos.String() + ")"
+}
+
+// Inspect is used for debugging
+func (e *Error) Inspect() string {
+	var out strings.Builder
+
+	out.WriteString("ERROR: ")
+	if e.Pos.IsValid() {
+		out.WriteString(e.Pos.String() + ": ")
+	}
+	out.WriteString(e.Message)
+
+	for _, frame := range e.Stack {
+		out.WriteString("\n  " + frame.String())
+	}
+
+	return out.String()
+}
+
+// Type returns the error type
+func (e *Error) Type() Type {
+	return ERROR_OBJ
+}
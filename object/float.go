@@ -0,0 +1,28 @@
+package object
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Float represents a 64-bit floating point value
+type Float struct {
+	Value float64
+}
+
+// Inspect is used for debugging
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+
+	// make sure whole floats don't look like integers (NaN and ±Inf are fine)
+	if !strings.ContainsAny(s, ".eEnN") {
+		s += ".0"
+	}
+
+	return s
+}
+
+// Type returns the float type
+func (f *Float) Type() Type {
+	return FLOAT_OBJ
+}
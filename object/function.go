@@ -0,0 +1,46 @@
+package object
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fr3fou/monkey/ast"
+)
+
+// Function represents a function value, it keeps a reference
+// to the environment it was defined in, so that it can form closures
+type Function struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatement
+	Env        *Environment
+
+	// Name is empty for anonymous functions
+	Name string
+}
+
+// Inspect is used for debugging
+func (f *Function) Inspect() string {
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range f.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString("fun")
+	if f.Name != "" {
+		out.WriteString(" " + f.Name)
+	}
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") {\n")
+	out.WriteString(f.Body.String())
+	out.WriteString("\n}")
+
+	return out.String()
+}
+
+// Type returns the function type
+func (f *Function) Type() Type {
+	return FUNCTION_OBJ
+}
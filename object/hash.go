@@ -0,0 +1,165 @@
+package object
+
+import (
+	"bytes"
+	"hash/fnv"
+	"strings"
+)
+
+// HashKey is used to look up values in a Hash,
+// equal objects always have equal hash keys
+// (but different objects might have equal ones too)
+type HashKey struct {
+	Type  Type
+	Value uint64
+}
+
+// Hashable is implemented by all objects that can be used as hash keys
+type Hashable interface {
+	Object
+	HashKey() HashKey
+}
+
+// HashKey returns the key of the integer
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+// HashKey returns the key of the big integer
+func (bi *BigInteger) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(bi.Value.String()))
+
+	return HashKey{Type: bi.Type(), Value: h.Sum64()}
+}
+
+// HashKey returns the key of the boolean
+func (b *Boolean) HashKey() HashKey {
+	var value uint64
+
+	if b.Value {
+		value = 1
+	}
+
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+// HashKey returns the key of the string
+func (s *String) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(s.Value))
+
+	return HashKey{Type: s.Type(), Value: h.Sum64()}
+}
+
+// HashPair holds the original key alongside its value
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// Hash represents a hash map, it remembers the order
+// in which keys were first inserted
+type Hash struct {
+	// pairs are kept in insertion order
+	pairs []HashPair
+
+	// buckets holds the indexes in pairs of all the keys
+	// with the given hash key, so that colliding keys don't overwrite each other
+	buckets map[HashKey][]int
+}
+
+// NewHash returns a pointer to an empty Hash
+func NewHash() *Hash {
+	return &Hash{
+		buckets: make(map[HashKey][]int),
+	}
+}
+
+// Get returns the value stored under the given key
+func (h *Hash) Get(key Hashable) (Object, bool) {
+	i, ok := h.index(key)
+	if !ok {
+		return nil, false
+	}
+
+	return h.pairs[i].Value, true
+}
+
+// Set stores the value under the given key,
+// overwriting any previous value without changing its order
+func (h *Hash) Set(key Hashable, value Object) {
+	if i, ok := h.index(key); ok {
+		h.pairs[i].Value = value
+		return
+	}
+
+	hashKey := key.HashKey()
+	h.buckets[hashKey] = append(h.buckets[hashKey], len(h.pairs))
+	h.pairs = append(h.pairs, HashPair{Key: key, Value: value})
+}
+
+// index returns the position of the key in pairs
+func (h *Hash) index(key Hashable) (int, bool) {
+	for _, i := range h.buckets[key.HashKey()] {
+		if keysEqual(h.pairs[i].Key, key) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// keysEqual checks if the two keys have the same type and value
+func keysEqual(a, b Object) bool {
+	switch a := a.(type) {
+	case *Integer:
+		b, ok := b.(*Integer)
+		return ok && a.Value == b.Value
+	case *BigInteger:
+		b, ok := b.(*BigInteger)
+		return ok && a.Value.Cmp(b.Value) == 0
+	case *Boolean:
+		b, ok := b.(*Boolean)
+		return ok && a.Value == b.Value
+	case *String:
+		b, ok := b.(*String)
+		return ok && a.Value == b.Value
+	default:
+		return a.Type() == b.Type() && a.Inspect() == b.Inspect()
+	}
+}
+
+// Len returns the amount of pairs in the hash
+func (h *Hash) Len() int {
+	return len(h.pairs)
+}
+
+// Pairs returns all of the pairs in insertion order
+func (h *Hash) Pairs() []HashPair {
+	pairs := make([]HashPair, len(h.pairs))
+	copy(pairs, h.pairs)
+
+	return pairs
+}
+
+// Inspect is used for debugging
+func (h *Hash) Inspect() string {
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for _, pair := range h.Pairs() {
+		pairs = append(pairs, pair.Key.Inspect()+": "+pair.Value.Inspect())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// Type returns the hash type
+func (h *Hash) Type() Type {
+	return HASH_OBJ
+}
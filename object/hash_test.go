@@ -0,0 +1,57 @@
+package object
+
+import "testing"
+
+// collidingKey is a key whose hash key is the same for all values
+type collidingKey struct {
+	String
+}
+
+func (ck *collidingKey) HashKey() HashKey {
+	return HashKey{Type: ck.Type(), Value: 42}
+}
+
+func TestHashCollisions(t *testing.T) {
+	h := NewHash()
+
+	a := &collidingKey{String{Value: "a"}}
+	b := &collidingKey{String{Value: "b"}}
+
+	h.Set(a, &Integer{Value: 1})
+	h.Set(b, &Integer{Value: 2})
+	h.Set(&collidingKey{String{Value: "a"}}, &Integer{Value: 3})
+
+	if h.Len() != 2 {
+		t.Fatalf("hash has wrong number of pairs. expected=2, got=%d", h.Len())
+	}
+
+	tests := []struct {
+		key      Hashable
+		expected int64
+	}{
+		{a, 3},
+		{b, 2},
+	}
+
+	for _, tt := range tests {
+		value, ok := h.Get(tt.key)
+		if !ok {
+			t.Errorf("no value for key %s", tt.key.Inspect())
+			continue
+		}
+
+		if value.(*Integer).Value != tt.expected {
+			t.Errorf("wrong value for key %s. expected=%d, got=%d",
+				tt.key.Inspect(), tt.expected, value.(*Integer).Value)
+		}
+	}
+
+	if _, ok := h.Get(&collidingKey{String{Value: "c"}}); ok {
+		t.Errorf("found a value for a missing key")
+	}
+
+	pairs := h.Pairs()
+	if pairs[0].Key != a || pairs[1].Key != b {
+		t.Errorf("pairs aren't in insertion order. got=%v", pairs)
+	}
+}
@@ -0,0 +1,37 @@
+package object
+
+import "github.com/fr3fou/monkey/token"
+
+// Break signals the innermost loop to stop,
+// it's unwound through nested blocks just like a return value
+type Break struct {
+	// Pos is the position of the break statement
+	Pos token.Position
+}
+
+// Inspect is used for debugging
+func (b *Break) Inspect() string {
+	return "break"
+}
+
+// Type returns the break type
+func (b *Break) Type() Type {
+	return BREAK_OBJ
+}
+
+// Continue signals the innermost loop to skip to its next iteration,
+// it's unwound through nested blocks just like a return value
+type Continue struct {
+	// Pos is the position of the continue statement
+	Pos token.Position
+}
+
+// Inspect is used for debugging
+func (c *Continue) Inspect() string {
+	return "continue"
+}
+
+// Type returns the continue type
+func (c *Continue) Type() Type {
+	return CONTINUE_OBJ
+}
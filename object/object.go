@@ -10,7 +10,19 @@ type Object interface {
 type Type string
 
 const (
-	INTEGER_OBJ = "INTEGER"
-	BOOLEAN_OBJ = "BOOLEAN"
-	NULL_OBJ    = "NULL"
+	INTEGER_OBJ     = "INTEGER"
+	BIG_INTEGER_OBJ = "BIG_INTEGER"
+	FLOAT_OBJ       = "FLOAT"
+	BOOLEAN_OBJ     = "BOOLEAN"
+	NULL_OBJ        = "NULL"
+	STRING_OBJ      = "STRING"
+	ARRAY_OBJ       = "ARRAY"
+	HASH_OBJ        = "HASH"
+
+	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	BREAK_OBJ        = "BREAK"
+	CONTINUE_OBJ     = "CONTINUE"
+	FUNCTION_OBJ     = "FUNCTION"
+	BUILTIN_OBJ      = "BUILTIN"
+	ERROR_OBJ        = "ERROR"
 )
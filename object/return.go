@@ -0,0 +1,17 @@
+package object
+
+// ReturnValue wraps the value of a return statement,
+// so that it can be unwound through nested blocks
+type ReturnValue struct {
+	Value Object
+}
+
+// Inspect is used for debugging
+func (rv *ReturnValue) Inspect() string {
+	return rv.Value.Inspect()
+}
+
+// Type returns the return value type
+func (rv *ReturnValue) Type() Type {
+	return RETURN_VALUE_OBJ
+}
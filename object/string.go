@@ -0,0 +1,16 @@
+package object
+
+// String represents a string value
+type String struct {
+	Value string
+}
+
+// Inspect is used for debugging
+func (s *String) Inspect() string {
+	return s.Value
+}
+
+// Type returns the string type
+func (s *String) Type() Type {
+	return STRING_OBJ
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/fr3fou/monkey/ast"
+)
+
+// parseAssignExpression parses any assignment to a variable or an element
+// x = 5
+// x += 1
+// arr[0] = 5
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	exp := &ast.AssignExpression{
+		Token:    p.tok,
+		Target:   target,
+		Operator: p.tok.Literal,
+	}
+
+	if !p.checkAssignable(target) {
+		return nil
+	}
+
+	// assignments are right associative - a = b = 5 is a = (b = 5)
+	precedence := p.curPrecedence()
+	p.nextToken()
+	exp.Value = p.parseExpression(precedence - 1)
+
+	return exp
+}
+
+// checkAssignable makes sure that the target of the current operator
+// is something that can be assigned to - a variable or an element
+func (p *Parser) checkAssignable(target ast.Expression) bool {
+	switch target.(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+		return true
+	case nil:
+		// the target itself is broken, which has already been reported
+		return false
+	default:
+		p.addError(&ParseError{
+			Pos:     p.tok.Pos,
+			Found:   p.tok,
+			Message: fmt.Sprintf("cannot assign to %s", target.String()),
+			Hint:    "only variables and elements of arrays or hashes can be assigned to",
+		})
+		return false
+	}
+}
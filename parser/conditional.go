@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/token"
+)
+
+// parseConditionalExpression parses any conditional (ternary) expression,
+// it's right associative - a ? b : c ? d : e is a ? b : (c ? d : e)
+// x > 0 ? x : -x
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	expression := &ast.ConditionalExpression{
+		Token:     p.tok,
+		Condition: condition,
+	}
+
+	p.nextToken()
+	expression.Consequence = p.parseExpressionBeforeColon()
+
+	if !p.expectPeek(token.COLON) {
+		return nil
+	}
+
+	p.nextToken()
+	expression.Alternative = p.parseExpression(TERNARY - 1)
+
+	return expression
+}
+
+// nextTokIsConditional reports whether the next token is a `?` that starts
+// a conditional expression (a ? b : c) rather than a postfix `?` (a?)
+//
+// A `?` followed by something that can't start an expression is always postfix
+// and one followed by something that can only start an expression never is,
+// but -, ( and [ can go both ways (x? - 1 and c ? -1 : 1), so then it's
+// a conditional only if there's a `:` left for it further on
+func (p *Parser) nextTokIsConditional() bool {
+	if !p.nextTokIs(token.QUESTION) {
+		return false
+	}
+
+	after := p.peekToken(1).Type
+
+	if p.prefixParseFns[after] == nil {
+		return false
+	}
+
+	if p.infixParseFns[after] == nil && p.postfixParseFns[after] == nil {
+		return true
+	}
+
+	return p.unmatchedColons() > p.pendingColons()
+}
+
+// unmatchedColons returns the number of `:` after nextTok (a `?`) that aren't
+// taken by a `?` that comes after it, before the end of the expression
+func (p *Parser) unmatchedColons() int {
+	offset := p.nextTok.Pos.Offset
+
+	if _, ok := p.colons[offset]; !ok {
+		p.matchColons()
+	}
+
+	return p.colons[offset]
+}
+
+// matchColons walks back from the end of the expression to nextTok and
+// stores the number of unmatched `:` after every `?` on the way, so that
+// the `?`s in a chain (x? - y? - 1) don't each scan to the end again
+func (p *Parser) matchColons() {
+	end := p.expressionEnd()
+	next := p.peekToken(end).Type
+
+	// colons holds the unmatched `:` of every group that's open while walking back,
+	// the last one is the group of the current token
+	colons := []int{0}
+
+	for i := end - 1; i >= 0; i-- {
+		tok := p.peekToken(i)
+		group := len(colons) - 1
+
+		switch tok.Type {
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			colons = append(colons, 0)
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			if group > 0 {
+				colons = colons[:group]
+			}
+		case token.COMMA, token.SEMICOLON, token.LET, token.RETURN, token.WHILE, token.FOR, token.BREAK, token.CONTINUE:
+			// the end of an element or a statement inside of a group
+			colons[group] = 0
+		case token.COLON:
+			colons[group]++
+		case token.QUESTION:
+			p.colons[tok.Pos.Offset] = colons[group]
+
+			if colons[group] > 0 && p.prefixParseFns[next] != nil {
+				colons[group]--
+			}
+		}
+
+		next = tok.Type
+	}
+}
+
+// expressionEnd returns the position (after nextTok) of the token that ends the
+// expression - EOF, the end of the enclosing group, a `,` or the end of the statement
+func (p *Parser) expressionEnd() int {
+	depth := 0
+
+	for i := 1; ; i++ {
+		switch p.peekToken(i).Type {
+		case token.EOF:
+			return i
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			if depth == 0 {
+				return i
+			}
+
+			depth--
+		case token.COMMA, token.SEMICOLON, token.LET, token.RETURN, token.WHILE, token.FOR, token.BREAK, token.CONTINUE:
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+}
+
+// parseExpressionBeforeColon parses an expression that has to be followed by a `:`,
+// so that a `?` inside of it doesn't take that `:` for itself
+func (p *Parser) parseExpressionBeforeColon() ast.Expression {
+	p.awaitingColon = append(p.awaitingColon, p.nesting)
+	defer func() { p.awaitingColon = p.awaitingColon[:len(p.awaitingColon)-1] }()
+
+	return p.parseExpression(LOWEST)
+}
+
+// pendingColons returns the number of expressions that are
+// waiting for a `:` at the current nesting
+func (p *Parser) pendingColons() int {
+	pending := 0
+
+	for i := len(p.awaitingColon) - 1; i >= 0 && p.awaitingColon[i] == p.nesting; i-- {
+		pending++
+	}
+
+	return pending
+}
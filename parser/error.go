@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/fr3fou/monkey/token"
+)
+
+// ParseError describes a single syntax error in the input
+type ParseError struct {
+	// Pos is the position of the offending token (or character)
+	Pos token.Position
+
+	// Expected holds the tokens that would have been valid instead (if known)
+	Expected []token.Type
+
+	// Found is the offending token
+	Found token.Token
+
+	Message string
+
+	// Hint suggests how to fix the error (if there's a common fix)
+	Hint string
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Pos, e.Message)
+
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+
+	return msg
+}
+
+// closingTokens are the delimiters that close a list or a block
+var closingTokens = map[token.Type]bool{
+	token.RPAREN:   true,
+	token.RBRACE:   true,
+	token.RBRACKET: true,
+}
+
+// hintFor suggests a fix for the most common mistakes
+// that end up with an unexpected token
+func hintFor(expected token.Type, found token.Token) string {
+	switch {
+	case expected == token.ASSIGN && found.Type == token.EQ:
+		return "use = to bind a value, == compares two values"
+	case closingTokens[expected] && found.Type == token.EOF:
+		return fmt.Sprintf("is a closing %s missing?", expected)
+	case expected == token.COMMA:
+		return "elements have to be separated with commas"
+	default:
+		return ""
+	}
+}
+
+// addError records the error, unless the parser is still recovering from
+// a previous one - anything reported before it resynchronizes is most
+// likely caused by the first error, so it's dropped
+func (p *Parser) addError(err *ParseError) {
+	if p.panicking {
+		return
+	}
+
+	p.panicking = true
+	p.errors = append(p.errors, err)
+}
+
+// errorf records an error about the current token
+func (p *Parser) errorf(pos token.Position, format string, a ...interface{}) {
+	p.addError(&ParseError{
+		Pos:     pos,
+		Found:   p.tok,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// synchronize skips the tokens of the statement that caused an error,
+// until the start of the next one, so that parsing can carry on from there
+// without reporting errors caused by the leftovers of the broken statement
+//
+// The next statement starts after a `;` or at a statement keyword,
+// unless they're nested inside of a `{}` that is skipped over,
+// a `}` that closes the block the statement is in is left for the block to handle
+//
+// Any `{` that the broken statement has left open belongs to a hash literal
+// (blocks always recover on their own), it doesn't hide the end of the statement
+func (p *Parser) synchronize(start token.Token) {
+	p.panicking = false
+
+	// a stray `}` outside of any block
+	if p.braces < 0 {
+		p.braces = 0
+
+		if p.tokIs(token.RBRACE) {
+			p.nextToken()
+			return
+		}
+	}
+
+	// the broken statement already went past the end of the block
+	if p.braces < p.blockLevel {
+		return
+	}
+
+	// braces opened while skipping are above level
+	level := p.braces
+
+	for {
+		switch p.tok.Type {
+		case token.EOF:
+			return
+		case token.SEMICOLON:
+			if p.braces <= level {
+				// forget about the hash literals that were left open
+				p.braces = p.blockLevel
+				p.nextToken()
+				return
+			}
+		case token.RBRACE:
+			// a stray `}` outside of any block, skip it
+			if p.braces < 0 {
+				p.braces = 0
+				p.nextToken()
+				return
+			}
+
+			if p.braces < p.blockLevel {
+				return
+			}
+		case token.LET, token.RETURN, token.WHILE, token.FOR, token.BREAK, token.CONTINUE:
+			// the statement itself might be the broken one
+			if p.tok.Pos != start.Pos && p.braces <= level {
+				p.braces = p.blockLevel
+				return
+			}
+		case token.FUNCTION:
+			// only function declarations start a statement
+			if p.tok.Pos != start.Pos && p.braces <= level && p.nextTokIs(token.IDENT) {
+				p.braces = p.blockLevel
+				return
+			}
+		}
+
+		p.nextToken()
+	}
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/fr3fou/monkey/ast"
 	"github.com/fr3fou/monkey/token"
 )
@@ -18,6 +20,13 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 
 	leftExp := prefix()
 	for !p.nextTokIs(token.SEMICOLON) && precedence < p.peekPrecedence() {
+		postfix := p.postfixParseFns[p.nextTok.Type]
+		if postfix != nil && !p.nextTokIsConditional() {
+			p.nextToken()
+			leftExp = postfix(leftExp)
+			continue
+		}
+
 		infix := p.infixParseFns[p.nextTok.Type]
 
 		if infix == nil {
@@ -33,7 +42,7 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 
 // parsePrefixExpression parses any expression that has a prefix
 // -5
-// ++5
+// !true
 func (p *Parser) parsePrefixExpression() ast.Expression {
 	expression := &ast.PrefixExpression{
 		Token:    p.tok,
@@ -46,6 +55,50 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 	return expression
 }
 
+// parsePostfixExpression parses any expression that has a postfix,
+// only variables and elements can be incremented or decremented
+// x++
+// arr[0]--
+// f()?
+func (p *Parser) parsePostfixExpression(left ast.Expression) ast.Expression {
+	expression := &ast.PostfixExpression{
+		Token:    p.tok,
+		Operator: p.tok.Literal,
+		Left:     left,
+	}
+
+	if p.tokIs(token.QUESTION) {
+		return expression
+	}
+
+	if !p.checkAssignable(left) {
+		return nil
+	}
+
+	// x++ y is most likely a typo
+	if p.nextTokIsOperand() && p.nextTok.Pos.Line == p.tok.Pos.Line {
+		p.addError(&ParseError{
+			Pos:     p.nextTok.Pos,
+			Found:   p.nextTok,
+			Message: fmt.Sprintf("unexpected %s after %s", p.nextTok.Literal, expression.String()),
+			Hint:    "use ; to separate statements on the same line",
+		})
+		return nil
+	}
+
+	return expression
+}
+
+// nextTokIsOperand checks if the next token is an identifier or a literal
+func (p *Parser) nextTokIsOperand() bool {
+	switch p.nextTok.Type {
+	case token.IDENT, token.INT, token.FLOAT, token.STRING, token.TRUE, token.FALSE:
+		return true
+	default:
+		return false
+	}
+}
+
 // parseInfixExpression parses any infix expression
 // 5 + 5
 // 5 / 5
@@ -57,6 +110,13 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.curPrecedence()
+
+	// ** is right associative - 2 ** 3 ** 2 is 2 ** (3 ** 2),
+	// so the right side is allowed to take in another ** expression
+	if p.tokIs(token.POWER) {
+		precedence--
+	}
+
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
 
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/fr3fou/monkey/ast"
+)
+
+// parseFloatLiteral parses any float literal and applies
+// strconv.ParseFloat on it
+// 3.14, 1_000.5
+// 1e9
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{
+		Token: p.tok,
+	}
+
+	if !p.checkUnderscores(p.tok.Literal, 0, 10) {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(p.tok.Literal, 64)
+
+	// literals that are too large to be represented are errors too
+	if err != nil {
+		p.errorf(p.tok.Pos, "could not parse %q as float", p.tok.Literal)
+		return nil
+	}
+
+	lit.Value = value
+	return lit
+}
@@ -12,19 +12,61 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 		Token: p.tok,
 	}
 
-	if !p.expectPeek(token.LPAREN) {
+	if !p.parseFunction(exp) {
 		return nil
 	}
 
-	exp.Parameters = p.parseFunctionParameters()
+	return exp
+}
 
-	if !p.expectPeek(token.LBRACE) {
+// parseFunctionStatement parses any named function declaration
+// fun add(x, y) { x + y }
+func (p *Parser) parseFunctionStatement() *ast.FunctionStatement {
+	stmt := &ast.FunctionStatement{
+		Token: p.tok,
+	}
+
+	if !p.expectPeek(token.IDENT) {
 		return nil
 	}
 
-	exp.Body = p.parseBlockStatement()
+	stmt.Name = &ast.Identifier{
+		Token: p.tok,
+		Value: p.tok.Literal,
+	}
 
-	return exp
+	stmt.Function = &ast.FunctionLiteral{
+		Token: stmt.Token,
+		Name:  stmt.Name.Value,
+	}
+
+	if !p.parseFunction(stmt.Function) {
+		return nil
+	}
+
+	if p.nextTokIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseFunction parses the params and the body of the function,
+// starting from the token right before the `(`
+func (p *Parser) parseFunction(fn *ast.FunctionLiteral) bool {
+	if !p.expectPeek(token.LPAREN) {
+		return false
+	}
+
+	fn.Parameters = p.parseFunctionParameters()
+
+	if !p.expectPeek(token.LBRACE) {
+		return false
+	}
+
+	fn.Body = p.parseBlockStatement()
+
+	return true
 }
 
 // parseFunctionParameters parses params to functions
@@ -66,38 +108,40 @@ func (p *Parser) parseFunctionParameters() []*ast.Identifier {
 // foo(3,"hi")
 func (p *Parser) parseCallExpression(fn ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.tok, Function: fn}
-	exp.Arguments = p.parseCallArguments()
+	exp.Arguments = p.parseExpressionList(token.RPAREN)
 	return exp
 }
 
-// parseCallArguments parses the args to a function call
+// parseExpressionList parses comma separated expressions
+// up until the given closing token, e.g. the args to a function call
 // foo(3,1,"foo")
-func (p *Parser) parseCallArguments() []ast.Expression {
-	args := []ast.Expression{}
+// [1, 2, 3]
+func (p *Parser) parseExpressionList(end token.Type) []ast.Expression {
+	list := []ast.Expression{}
 
-	// handle functions with no arguments
-	if p.nextTokIs(token.RPAREN) {
+	// handle empty lists
+	if p.nextTokIs(end) {
 		p.nextToken()
-		return args
+		return list
 	}
 
-	// skip the first `(`
+	// skip the opening token
 	p.nextToken()
-	args = append(args, p.parseExpression(LOWEST))
+	list = append(list, p.parseExpression(LOWEST))
 
-	// make sure there are commas in between each param
+	// make sure there are commas in between each element
 	// (x,y,z,f)
 	for p.nextTokIs(token.COMMA) {
 		// skip comma
 		p.nextToken()
 		p.nextToken()
-		args = append(args, p.parseExpression(LOWEST))
+		list = append(list, p.parseExpression(LOWEST))
 	}
 
-	// if there isn't a closing `)`, return nil
-	if !p.expectPeek(token.RPAREN) {
+	// if there isn't a closing token, return nil
+	if !p.expectPeek(end) {
 		return nil
 	}
 
-	return args
+	return list
 }
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/token"
+)
+
+// parseHashLiteral parses any hash literal
+// {"one": 1, two: 2, 3: true}
+//
+// Blocks are only ever parsed right after the tokens that require them
+// (e.g. `if (x) {` or `fun() {`), so a `{` that starts an expression
+// (or a statement) is always a hash literal
+func (p *Parser) parseHashLiteral() ast.Expression {
+	hash := &ast.HashLiteral{
+		Token: p.tok,
+		Pairs: []ast.HashPair{},
+	}
+
+	for !p.nextTokIs(token.RBRACE) {
+		p.nextToken()
+		key := p.parseExpressionBeforeColon()
+
+		if !p.expectPeek(token.COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs = append(hash.Pairs, ast.HashPair{Key: key, Value: value})
+
+		// make sure there are commas in between each pair
+		if !p.nextTokIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	// skip the closing `}`
+	p.nextToken()
+
+	return hash
+}
@@ -1,27 +1,141 @@
 package parser
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/token"
 )
 
+// integerBases maps the prefixes of non-decimal literals to their base
+var integerBases = map[string]int{
+	"0x": 16,
+	"0X": 16,
+	"0o": 8,
+	"0O": 8,
+	"0b": 2,
+	"0B": 2,
+}
+
+// baseNames is used for nicer error messages
+var baseNames = map[int]string{
+	2:  "binary",
+	8:  "octal",
+	10: "decimal",
+	16: "hexadecimal",
+}
+
 // parseIntegerLiteral parses any integer literal and applies
 // strconv.ParseInt on it
+// 5, 1_000_000
+// 0xff, 0o755, 0b1010
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{
 		Token: p.tok,
 	}
 
-	value, err := strconv.ParseInt(p.tok.Literal, 0, 64)
+	literal := p.tok.Literal
+	base, start := 10, 0
+
+	if len(literal) >= 2 {
+		if b, ok := integerBases[literal[:2]]; ok {
+			base, start = b, 2
+		}
+	}
+
+	if !p.checkDigits(literal, start, base) {
+		return nil
+	}
+
+	value, err := strconv.ParseInt(strings.ReplaceAll(literal[start:], "_", ""), base, 64)
 
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.tok.Literal)
-		p.errors = append(p.errors, msg)
+		if errors.Is(err, strconv.ErrRange) {
+			p.errorf(p.tok.Pos, "integer literal %q overflows int64", literal)
+			return nil
+		}
+
+		p.errorf(p.tok.Pos, "could not parse %q as integer", literal)
 		return nil
 	}
 
 	lit.Value = value
 	return lit
 }
+
+// checkDigits makes sure that all of the digits of the current integer literal
+// (after the base prefix) are valid in its base and that it has at least one,
+// the errors point at the exact offending character
+func (p *Parser) checkDigits(literal string, start, base int) bool {
+	digits := 0
+
+	for i, ch := range literal[start:] {
+		if ch == '_' {
+			continue
+		}
+
+		if digitValue(ch) >= base {
+			p.errorf(literalPos(p.tok.Pos, literal, start+i),
+				"invalid digit %q in %s literal %q", ch, baseNames[base], literal)
+			return false
+		}
+
+		digits++
+	}
+
+	if digits == 0 {
+		p.errorf(p.tok.Pos, "%s literal %q has no digits", baseNames[base], literal)
+		return false
+	}
+
+	return p.checkUnderscores(literal, start, base)
+}
+
+// checkUnderscores makes sure that every `_` in the current number literal
+// separates two digits (or follows the base prefix, as in 0x_ff)
+func (p *Parser) checkUnderscores(literal string, start, base int) bool {
+	for i := 0; i < len(literal); i++ {
+		if literal[i] != '_' {
+			continue
+		}
+
+		afterPrefix := start > 0 && i == start
+		validBefore := afterPrefix || i > 0 && digitValue(rune(literal[i-1])) < base
+		validAfter := i+1 < len(literal) && digitValue(rune(literal[i+1])) < base
+
+		if !validBefore || !validAfter {
+			p.errorf(literalPos(p.tok.Pos, literal, i),
+				"'_' must separate successive digits in %q", literal)
+			return false
+		}
+	}
+
+	return true
+}
+
+// digitValue returns the numeric value of the digit
+// (up to base 36) or 36 if it isn't a digit at all
+func digitValue(ch rune) int {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'z':
+		return int(ch-'a') + 10
+	case 'A' <= ch && ch <= 'Z':
+		return int(ch-'A') + 10
+	default:
+		return 36
+	}
+}
+
+// literalPos returns the position of the byte at index i
+// in a literal that starts at pos
+func literalPos(pos token.Position, literal string, i int) token.Position {
+	pos.Column += utf8.RuneCountInString(literal[:i])
+	pos.Offset += i
+
+	return pos
+}
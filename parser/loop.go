@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"github.com/fr3fou/monkey/ast"
+	"github.com/fr3fou/monkey/token"
+)
+
+// parseWhileStatement parses any while loop
+// while (x < 10) { x }
+func (p *Parser) parseWhileStatement() ast.Statement {
+	stmt := &ast.WhileStatement{
+		Token: p.tok,
+	}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	// carry on with the condition
+	p.nextToken()
+	stmt.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Body = p.parseBlockStatement()
+
+	if p.nextTokIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseForStatement parses any for loop
+// for (x in [1, 2, 3]) { x }
+func (p *Parser) parseForStatement() ast.Statement {
+	stmt := &ast.ForStatement{
+		Token: p.tok,
+	}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+
+	stmt.Variable = &ast.Identifier{
+		Token: p.tok,
+		Value: p.tok.Literal,
+	}
+
+	if !p.expectPeek(token.IN) {
+		return nil
+	}
+
+	// carry on with the iterable
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Body = p.parseBlockStatement()
+
+	if p.nextTokIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseBreakStatement parses any break statement (break;)
+func (p *Parser) parseBreakStatement() ast.Statement {
+	stmt := &ast.BreakStatement{
+		Token: p.tok,
+	}
+
+	if p.nextTokIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseContinueStatement parses any continue statement (continue;)
+func (p *Parser) parseContinueStatement() ast.Statement {
+	stmt := &ast.ContinueStatement{
+		Token: p.tok,
+	}
+
+	if p.nextTokIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
@@ -9,51 +9,107 @@ import (
 )
 
 type (
-	prefixParseFn func() ast.Expression
-	infixParseFn  func(ast.Expression) ast.Expression
+	prefixParseFn  func() ast.Expression
+	infixParseFn   func(ast.Expression) ast.Expression
+	postfixParseFn func(ast.Expression) ast.Expression
 )
 
 // Precendence order
 const (
 	_ int = iota
 	LOWEST
+	ASSIGNMENT  // x = 5 or x += 5
+	TERNARY     // X ? Y : Z
+	COALESCE    // X ?? Y
+	LOGICAL_OR  // ||
+	LOGICAL_AND // &&
+	BIT_OR      // |
+	BIT_XOR     // ^
+	BIT_AND     // &
 	EQUALS      // ==
-	LESSGREATER // > or <
+	LESSGREATER // >, <, >= or <=
+	SHIFT       // << or >>
 	SUM         // +
 	PRODUCT     // *
-	PREFIX      // -X or !X
+	PREFIX      // -X, !X or ~X
+	EXPONENT    // X ** Y
+	POSTFIX     // X++, X-- or X?
 	CALL        // myFunction(X)
+	INDEX       // array[index]
 )
 
 // Precendence order
 var precedences = map[token.Type]int{
+	token.ASSIGN:          ASSIGNMENT,
+	token.PLUS_ASSIGN:     ASSIGNMENT,
+	token.MINUS_ASSIGN:    ASSIGNMENT,
+	token.ASTERISK_ASSIGN: ASSIGNMENT,
+	token.SLASH_ASSIGN:    ASSIGNMENT,
+	token.COALESCE:        COALESCE,
+
+	token.OR:       LOGICAL_OR,
+	token.AND:      LOGICAL_AND,
+	token.BIT_OR:   BIT_OR,
+	token.BIT_XOR:  BIT_XOR,
+	token.BIT_AND:  BIT_AND,
 	token.EQ:       EQUALS,
 	token.NEQ:      EQUALS,
 	token.LT:       LESSGREATER,
 	token.GT:       LESSGREATER,
+	token.LTE:      LESSGREATER,
+	token.GTE:      LESSGREATER,
+	token.SHL:      SHIFT,
+	token.SHR:      SHIFT,
 	token.PLUS:     SUM,
 	token.MINUS:    SUM,
 	token.SLASH:    PRODUCT,
 	token.ASTERISK: PRODUCT,
+	token.PERCENT:  PRODUCT,
+	token.POWER:    EXPONENT,
 	token.LPAREN:   CALL,
+	token.LBRACKET: INDEX,
+
+	token.INCREMENT: POSTFIX,
+	token.DECREMENT: POSTFIX,
+	token.QUESTION:  POSTFIX,
 }
 
 // Parser is the struct which does all of the parsing
 type Parser struct {
-	l              *lexer.Lexer
-	tok            token.Token
-	nextTok        token.Token
-	errors         []string
-	prefixParseFns map[token.Type]prefixParseFn
-	infixParseFns  map[token.Type]infixParseFn
-	// TODO: implement postfix too
+	l               *lexer.Lexer
+	tok             token.Token
+	nextTok         token.Token
+	ahead           []token.Token // tokens after nextTok that have been read to scan ahead
+	errors          []*ParseError
+	prefixParseFns  map[token.Type]prefixParseFn
+	infixParseFns   map[token.Type]infixParseFn
+	postfixParseFns map[token.Type]postfixParseFn
+
+	// panicking is set after an error, until the parser resynchronizes
+	panicking bool
+
+	// braces is the number of currently open `{`,
+	// blockLevel is the number of them when the current block was opened
+	braces     int
+	blockLevel int
+
+	// nesting is the number of currently open `(`, `[` and `{`,
+	// awaitingColon holds the nesting of every expression that is being parsed
+	// and has to be followed by a `:` (a consequence or a hash key)
+	nesting       int
+	awaitingColon []int
+
+	// colons holds the number of unmatched `:` after every `?` that
+	// has already been scanned ahead for, by the `?`'s offset
+	colons map[int]int
 }
 
 // New returns a pointer to a parser
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:      l,
-		errors: []string{},
+		errors: []*ParseError{},
+		colons: map[int]int{},
 	}
 
 	// Read two tokens, so curToken and peekToken are both set
@@ -66,11 +122,16 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.TRUE, p.parseBoolean)
 	p.registerPrefix(token.FALSE, p.parseBoolean)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(token.STRING, p.parseStringLiteral)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(token.BIT_NOT, p.parsePrefixExpression)
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(token.IF, p.parseIfExpression)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
+	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
+	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
 
 	p.infixParseFns = make(map[token.Type]infixParseFn)
 
@@ -78,19 +139,91 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.MINUS, p.parseInfixExpression)
 	p.registerInfix(token.SLASH, p.parseInfixExpression)
 	p.registerInfix(token.ASTERISK, p.parseInfixExpression)
+	p.registerInfix(token.PERCENT, p.parseInfixExpression)
+	p.registerInfix(token.POWER, p.parseInfixExpression)
 	p.registerInfix(token.EQ, p.parseInfixExpression)
 	p.registerInfix(token.NEQ, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
+	p.registerInfix(token.LTE, p.parseInfixExpression)
+	p.registerInfix(token.GTE, p.parseInfixExpression)
+	p.registerInfix(token.AND, p.parseInfixExpression)
+	p.registerInfix(token.OR, p.parseInfixExpression)
+	p.registerInfix(token.COALESCE, p.parseInfixExpression)
+	p.registerInfix(token.QUESTION, p.parseConditionalExpression)
+	p.registerInfix(token.BIT_AND, p.parseInfixExpression)
+	p.registerInfix(token.BIT_OR, p.parseInfixExpression)
+	p.registerInfix(token.BIT_XOR, p.parseInfixExpression)
+	p.registerInfix(token.SHL, p.parseInfixExpression)
+	p.registerInfix(token.SHR, p.parseInfixExpression)
+	p.registerInfix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.SLASH_ASSIGN, p.parseAssignExpression)
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
+	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
+
+	p.postfixParseFns = make(map[token.Type]postfixParseFn)
+
+	p.registerPostfix(token.INCREMENT, p.parsePostfixExpression)
+	p.registerPostfix(token.DECREMENT, p.parsePostfixExpression)
+	p.registerPostfix(token.QUESTION, p.parsePostfixExpression)
 
 	return p
 }
 
-// nextToken is a helper function that advances through the tokens
+// nextToken is a helper function that advances through the tokens,
+// comments don't mean anything to the parser, so they're skipped
 func (p *Parser) nextToken() {
 	p.tok = p.nextTok
-	p.nextTok = p.l.NextToken()
+
+	if len(p.ahead) > 0 {
+		p.nextTok = p.ahead[0]
+		p.ahead = p.ahead[1:]
+	} else {
+		p.nextTok = p.readToken()
+	}
+
+	switch p.tok.Type {
+	case token.LBRACE:
+		p.braces++
+		p.nesting++
+	case token.RBRACE:
+		p.braces--
+		p.nesting--
+	case token.LPAREN, token.LBRACKET:
+		p.nesting++
+	case token.RPAREN, token.RBRACKET:
+		p.nesting--
+	case token.QUESTION:
+		delete(p.colons, p.tok.Pos.Offset)
+	}
+}
+
+// readToken reads the next token from the lexer, skipping comments
+func (p *Parser) readToken() token.Token {
+	tok := p.l.NextToken()
+
+	for tok.Type == token.COMMENT {
+		tok = p.l.NextToken()
+	}
+
+	return tok
+}
+
+// peekToken returns the token that is i tokens after nextTok
+// (nextTok itself for 0), without advancing
+func (p *Parser) peekToken(i int) token.Token {
+	if i == 0 {
+		return p.nextTok
+	}
+
+	for len(p.ahead) < i {
+		p.ahead = append(p.ahead, p.readToken())
+	}
+
+	return p.ahead[i-1]
 }
 
 // ParseProgram starts parsing the program using our lexer
@@ -100,8 +233,15 @@ func (p *Parser) ParseProgram() *ast.Program {
 	}
 
 	for !p.tokIs(token.EOF) {
+		start := p.tok
 		stmt := p.parseStatement()
 
+		// skip over the rest of the broken statement
+		if p.panicking {
+			p.synchronize(start)
+			continue
+		}
+
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -139,21 +279,34 @@ func (p *Parser) expectPeek(t token.Type) bool {
 // peekError is a helper function that
 // is used when meeting an unexpected token when peeking
 func (p *Parser) peekError(t token.Type) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s instead",
-		t, p.nextTok.Type)
-	p.errors = append(p.errors, msg)
+	p.addError(&ParseError{
+		Pos:      p.nextTok.Pos,
+		Expected: []token.Type{t},
+		Found:    p.nextTok,
+		Message:  fmt.Sprintf("expected next token to be %s, got %s instead", t, p.nextTok.Type),
+		Hint:     hintFor(t, p.nextTok),
+	})
 }
 
 // noPrefixParseFnError is a helper function
 // that formats a better error when missing a prefix fn
 func (p *Parser) noPrefixParseFnError(t token.Type) {
-	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	// the lexer couldn't make sense of it, so show what it was
+	if t == token.ILLEGAL {
+		p.errorf(p.tok.Pos, "illegal token %q", p.tok.Literal)
+		return
+	}
+
+	p.errorf(p.tok.Pos, "no prefix parse function for %s found", t)
 }
 
 // curPrecedence is a helper function that returns the precedence
 // of the next token type
 func (p *Parser) peekPrecedence() int {
+	if p.nextTokIsConditional() {
+		return TERNARY
+	}
+
 	if p, ok := precedences[p.nextTok.Type]; ok {
 		return p
 	}
@@ -173,7 +326,7 @@ func (p *Parser) curPrecedence() int {
 
 // Errors is a function that returns all of the errors
 // that the parser met during parsing
-func (p *Parser) Errors() []string {
+func (p *Parser) Errors() []*ParseError {
 	return p.errors
 }
 
@@ -188,3 +341,9 @@ func (p *Parser) registerPrefix(tokenType token.Type, fn prefixParseFn) {
 func (p *Parser) registerInfix(tokenType token.Type, fn infixParseFn) {
 	p.infixParseFns[tokenType] = fn
 }
+
+// registerPostfix is a helper function that adds the provided function in the map
+// for postfix functions
+func (p *Parser) registerPostfix(tokenType token.Type, fn postfixParseFn) {
+	p.postfixParseFns[tokenType] = fn
+}
@@ -2,10 +2,13 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fr3fou/monkey/ast"
 	"github.com/fr3fou/monkey/lexer"
+	"github.com/fr3fou/monkey/token"
 )
 
 func TestLetStatements(t *testing.T) {
@@ -140,6 +143,108 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestFloatLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e3;", 1000},
+		{"2.5E-1;", 0.25},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.FloatLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.FloatLiteral. got=%T", stmt.Expression)
+		}
+
+		if literal.Value != tt.expected {
+			t.Errorf("literal.Value not %g. got=%g", tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestIntegerLiteralBases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0", 0},
+		{"007", 7},
+		{"1_000_000", 1000000},
+		{"0xff", 255},
+		{"0XFF", 255},
+		{"0x_dead_beef", 0xdeadbeef},
+		{"0o755", 0755},
+		{"0O17", 15},
+		{"0b1010", 10},
+		{"0B1111_0000", 240},
+		{"0x7fffffffffffffff", 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+		}
+
+		if literal.Value != tt.expected {
+			t.Errorf("literal.Value not %d. got=%d", tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestNumberLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"0x", `1:1: hexadecimal literal "0x" has no digits`},
+		{"0b_", `1:1: binary literal "0b_" has no digits`},
+		{"let x = 0xfg;", `1:12: invalid digit 'g' in hexadecimal literal "0xfg"`},
+		{"0o789", `1:4: invalid digit '8' in octal literal "0o789"`},
+		{"0b102", `1:5: invalid digit '2' in binary literal "0b102"`},
+		{"0xЖ", `1:3: invalid digit 'Ж' in hexadecimal literal "0xЖ"`},
+		{"1__000", `1:2: '_' must separate successive digits in "1__000"`},
+		{"1000_", `1:5: '_' must separate successive digits in "1000_"`},
+		{"0x_", `1:1: hexadecimal literal "0x_" has no digits`},
+		{"0xff_", `1:5: '_' must separate successive digits in "0xff_"`},
+		{"1_.5", `1:2: '_' must separate successive digits in "1_.5"`},
+		{"1.5_e3", `1:4: '_' must separate successive digits in "1.5_e3"`},
+		{"0x8000000000000000", `1:1: integer literal "0x8000000000000000" overflows int64`},
+		{"0b" + strings.Repeat("1", 64), `1:1: integer literal "0b` + strings.Repeat("1", 64) + `" overflows int64`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q, got none", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expectedError {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
 func TestParsingPrefixExpressions(t *testing.T) {
 	prefixTests := []struct {
 		input    string
@@ -150,6 +255,8 @@ func TestParsingPrefixExpressions(t *testing.T) {
 		{"-15;", "-", 15},
 		{"!foobar;", "!", "foobar"},
 		{"-foobar;", "-", "foobar"},
+		{"~15;", "~", 15},
+		{"~foobar;", "~", "foobar"},
 		{"!true;", "!", true},
 		{"!false;", "!", false},
 	}
@@ -200,6 +307,15 @@ func TestParsingInfixExpressions(t *testing.T) {
 		{"5 < 5;", 5, "<", 5},
 		{"5 == 5;", 5, "==", 5},
 		{"5 != 5;", 5, "!=", 5},
+		{"5 >= 5;", 5, ">=", 5},
+		{"5 <= 5;", 5, "<=", 5},
+		{"5 % 5;", 5, "%", 5},
+		{"5 ** 5;", 5, "**", 5},
+		{"5 & 5;", 5, "&", 5},
+		{"5 | 5;", 5, "|", 5},
+		{"5 ^ 5;", 5, "^", 5},
+		{"5 << 5;", 5, "<<", 5},
+		{"5 >> 5;", 5, ">>", 5},
 		{"foobar + barfoo;", "foobar", "+", "barfoo"},
 		{"foobar - barfoo;", "foobar", "-", "barfoo"},
 		{"foobar * barfoo;", "foobar", "*", "barfoo"},
@@ -211,6 +327,8 @@ func TestParsingInfixExpressions(t *testing.T) {
 		{"true == true", true, "==", true},
 		{"true != false", true, "!=", false},
 		{"false == false", false, "==", false},
+		{"true && false", true, "&&", false},
+		{"a || b", "a", "||", "b"},
 	}
 
 	for _, tt := range infixTests {
@@ -342,6 +460,250 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a + b + c * d / f + g)",
 			"add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"a * [1, 2, 3, 4][b * c] * d",
+			"((a * ([1, 2, 3, 4][(b * c)])) * d)",
+		},
+		{
+			"add(a * b[2], b[1], 2 * [1, 2][1])",
+			"add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
+		},
+		{
+			"a + b % c * d",
+			"(a + ((b % c) * d))",
+		},
+		{
+			"a <= b == b >= a",
+			"((a <= b) == (b >= a))",
+		},
+		{
+			"2 ** 3 ** 2",
+			"(2 ** (3 ** 2))",
+		},
+		{
+			"-2 ** 2",
+			"(-(2 ** 2))",
+		},
+		{
+			"2 ** -1",
+			"(2 ** (-1))",
+		},
+		{
+			"a * b ** c * d",
+			"((a * (b ** c)) * d)",
+		},
+		{
+			"a ** b[0] ** f(c)",
+			"(a ** ((b[0]) ** f(c)))",
+		},
+		{
+			"a | b ^ c & d",
+			"(a | (b ^ (c & d)))",
+		},
+		{
+			"a & b | c & d",
+			"((a & b) | (c & d))",
+		},
+		{
+			"a & 1 == 0",
+			"(a & (1 == 0))",
+		},
+		{
+			"1 << 2 + 3 < 4 >> 1",
+			"((1 << (2 + 3)) < (4 >> 1))",
+		},
+		{
+			"~a & ~b",
+			"((~a) & (~b))",
+		},
+		{
+			"a && b | c || d",
+			"((a && (b | c)) || d)",
+		},
+		{
+			"-x++",
+			"(-(x++))",
+		},
+		{
+			"x++ + y--",
+			"((x++) + (y--))",
+		},
+		{
+			"a--b",
+			"(a - (-b))",
+		},
+		{
+			"--5",
+			"(-(-5))",
+		},
+		{
+			"5--3",
+			"(5 - (-3))",
+		},
+		{
+			"x---y",
+			"((x--) - y)",
+		},
+		{
+			"x--\ny",
+			"(x--)y",
+		},
+		{
+			"a[0]++ * 2",
+			"(((a[0])++) * 2)",
+		},
+		{
+			"2 ** x--",
+			"(2 ** (x--))",
+		},
+		{
+			"!f(x)?",
+			"(!(f(x)?))",
+		},
+		{
+			"a?[0]?",
+			"(((a?)[0])?)",
+		},
+		{
+			"f()?(1)",
+			"(f()?)(1)",
+		},
+		{
+			"f()? * 2",
+			"((f()?) * 2)",
+		},
+		{
+			"a ? b : c",
+			"(a ? b : c)",
+		},
+		{
+			"a || b ? x + 1 : y",
+			"((a || b) ? (x + 1) : y)",
+		},
+		{
+			"a ? b : c ? d : e",
+			"(a ? b : (c ? d : e))",
+		},
+		{
+			"a ? b ? c : d : e",
+			"(a ? (b ? c : d) : e)",
+		},
+		{
+			"x = a ? b : c",
+			"(x = (a ? b : c))",
+		},
+		{
+			"a? ? b : c",
+			"((a?) ? b : c)",
+		},
+		{
+			"a ?? b || c",
+			"(a ?? (b || c))",
+		},
+		{
+			"a == b ?? c",
+			"((a == b) ?? c)",
+		},
+		{
+			"a ?? b ?? c",
+			"((a ?? b) ?? c)",
+		},
+		{
+			"a ?? b ? c : d",
+			"((a ?? b) ? c : d)",
+		},
+		{
+			`h["a"]? - 1`,
+			`(((h["a"])?) - 1)`,
+		},
+		{
+			"x? -1",
+			"((x?) - 1)",
+		},
+		{
+			"c ? -1 : 1",
+			"(c ? (-1) : 1)",
+		},
+		{
+			"c ? (x) : [y]",
+			"(c ? x : [y])",
+		},
+		{
+			"c ? h? - 1 : 2",
+			"(c ? ((h?) - 1) : 2)",
+		},
+		{
+			"c ? f()?(1) : [1]?[0]",
+			"(c ? (f()?)(1) : (([1]?)[0]))",
+		},
+		{
+			"x? - y ? -1 : 2",
+			"(((x?) - y) ? (-1) : 2)",
+		},
+		{
+			"x? - c ? y? - 1 : z? - 2",
+			"(((x?) - c) ? ((y?) - 1) : ((z?) - 2))",
+		},
+		{
+			"f()?(1) ? a : b",
+			"((f()?)(1) ? a : b)",
+		},
+		{
+			"f(x? - 1, c ? -1 : 1)",
+			"f(((x?) - 1), (c ? (-1) : 1))",
+		},
+		{
+			`{"a": x? - 1, "b": c ? [1] : [2]}`,
+			`{"a": ((x?) - 1), "b": (c ? [1] : [2])}`,
+		},
+		{
+			`{x? - 1: c ? -1 : 1}`,
+			"{((x?) - 1): (c ? (-1) : 1)}",
+		},
+		{
+			"let y = x? - 1\nlet z = c ? -1 : 1",
+			"let y = ((x?) - 1);let z = (c ? (-1) : 1);",
+		},
+		{
+			"a = b++",
+			"(a = (b++))",
+		},
+		{
+			"a = b = c",
+			"(a = (b = c))",
+		},
+		{
+			"x += 1 * 2",
+			"(x += (1 * 2))",
+		},
+		{
+			"a[0] = b || c",
+			"((a[0]) = (b || c))",
+		},
+		{
+			"a || b && c",
+			"(a || (b && c))",
+		},
+		{
+			"a && b || c && d",
+			"((a && b) || (c && d))",
+		},
+		{
+			"a == b && c != d || !e",
+			"(((a == b) && (c != d)) || (!e))",
+		},
+		{
+			"a < b || a + 1 > b * 2",
+			"((a < b) || ((a + 1) > (b * 2)))",
+		},
+		{
+			"-a[0]",
+			"(-(a[0]))",
+		},
+		{
+			"a[0][1](2)[3]",
+			"(((a[0])[1])(2)[3])",
+		},
 	}
 
 	for _, tt := range tests {
@@ -357,6 +719,49 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 	}
 }
 
+func TestStringLiteralExpression(t *testing.T) {
+	input := `"hello\tworld";`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	literal, ok := stmt.Expression.(*ast.StringLiteral)
+	if !ok {
+		t.Fatalf("exp not *ast.StringLiteral. got=%T", stmt.Expression)
+	}
+
+	if literal.Value != "hello\tworld" {
+		t.Errorf("literal.Value not %q. got=%q", "hello\tworld", literal.Value)
+	}
+
+	if literal.String() != `"hello\tworld"` {
+		t.Errorf("literal.String() not %q. got=%q", `"hello\tworld"`, literal.String())
+	}
+}
+
+func TestCommentsAreIgnored(t *testing.T) {
+	input := `// adds two numbers
+let add = fun(x, y) { /* the sum */ x + y }; // done
+add(1, /* two */ 2)`
+
+	for _, emit := range []bool{false, true} {
+		l := lexer.New(input)
+		l.EmitComments(emit)
+
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		expected := "let add = fun(x, y)(x + y);add(1, 2)"
+		if program.String() != expected {
+			t.Errorf("expected=%q, got=%q", expected, program.String())
+		}
+	}
+}
+
 func TestBooleanExpression(t *testing.T) {
 	tests := []struct {
 		input           string
@@ -502,8 +907,8 @@ func TestIfElseExpression(t *testing.T) {
 	}
 }
 
-func TestFunctionLiteralParsing(t *testing.T) {
-	input := `fun(x, y) { x + y; }`
+func TestWhileStatement(t *testing.T) {
+	input := `while (x < y) { x; break; continue; }`
 
 	l := lexer.New(input)
 	p := New(l)
@@ -515,62 +920,482 @@ func TestFunctionLiteralParsing(t *testing.T) {
 			1, len(program.Statements))
 	}
 
-	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
 	if !ok {
-		t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T",
+		t.Fatalf("program.Statements[0] is not ast.WhileStatement. got=%T",
 			program.Statements[0])
 	}
 
-	function, ok := stmt.Expression.(*ast.FunctionLiteral)
+	if !testInfixExpression(t, stmt.Condition, "x", "<", "y") {
+		return
+	}
+
+	if len(stmt.Body.Statements) != 3 {
+		t.Fatalf("body is not 3 statements. got=%d\n",
+			len(stmt.Body.Statements))
+	}
+
+	if _, ok := stmt.Body.Statements[1].(*ast.BreakStatement); !ok {
+		t.Errorf("Statements[1] is not ast.BreakStatement. got=%T",
+			stmt.Body.Statements[1])
+	}
+
+	if _, ok := stmt.Body.Statements[2].(*ast.ContinueStatement); !ok {
+		t.Errorf("Statements[2] is not ast.ContinueStatement. got=%T",
+			stmt.Body.Statements[2])
+	}
+}
+
+func TestForStatement(t *testing.T) {
+	input := `for (x in [1, 2]) { x }; x`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+			2, len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ForStatement)
 	if !ok {
-		t.Fatalf("stmt.Expression is not ast.FunctionLiteral. got=%T",
-			stmt.Expression)
+		t.Fatalf("program.Statements[0] is not ast.ForStatement. got=%T",
+			program.Statements[0])
 	}
 
-	if len(function.Parameters) != 2 {
-		t.Fatalf("function literal parameters wrong. want 2, got=%d\n",
-			len(function.Parameters))
+	if !testIdentifier(t, stmt.Variable, "x") {
+		return
 	}
 
-	testLiteralExpression(t, function.Parameters[0], "x")
-	testLiteralExpression(t, function.Parameters[1], "y")
+	if stmt.Iterable.String() != "[1, 2]" {
+		t.Errorf("stmt.Iterable is not %q. got=%q", "[1, 2]", stmt.Iterable.String())
+	}
 
-	if len(function.Body.Statements) != 1 {
-		t.Fatalf("function.Body.Statements has not 1 statements. got=%d\n",
-			len(function.Body.Statements))
+	if len(stmt.Body.Statements) != 1 {
+		t.Fatalf("body is not 1 statements. got=%d\n",
+			len(stmt.Body.Statements))
 	}
 
-	bodyStmt, ok := function.Body.Statements[0].(*ast.ExpressionStatement)
+	body, ok := stmt.Body.Statements[0].(*ast.ExpressionStatement)
 	if !ok {
-		t.Fatalf("function body stmt is not ast.ExpressionStatement. got=%T",
-			function.Body.Statements[0])
+		t.Fatalf("Statements[0] is not ast.ExpressionStatement. got=%T",
+			stmt.Body.Statements[0])
 	}
 
-	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+	testIdentifier(t, body.Expression, "x")
 }
 
-func TestFunctionParameterParsing(t *testing.T) {
+func TestLoopStatementErrors(t *testing.T) {
 	tests := []struct {
-		input          string
-		expectedParams []string
+		input         string
+		expectedError string
 	}{
-		{input: "fun() {};", expectedParams: []string{}},
-		{input: "fun(x) {};", expectedParams: []string{"x"}},
-		{input: "fun(x, y, z) {};", expectedParams: []string{"x", "y", "z"}},
+		{"while x { x }", "1:7: expected next token to be (, got IDENT instead"},
+		{"while (x) x", "1:11: expected next token to be {, got IDENT instead"},
+		{"for (x of y) { x }", "1:8: expected next token to be IN, got IDENT instead"},
+		{"for (1 in y) { x }", "1:6: expected next token to be IDENT, got INT instead"},
 	}
 
 	for _, tt := range tests {
 		l := lexer.New(tt.input)
 		p := New(l)
-		program := p.ParseProgram()
-		checkParserErrors(t, p)
-
-		stmt := program.Statements[0].(*ast.ExpressionStatement)
-		function := stmt.Expression.(*ast.FunctionLiteral)
+		p.ParseProgram()
 
-		if len(function.Parameters) != len(tt.expectedParams) {
-			t.Errorf("length parameters wrong. want %d, got=%d\n",
-				len(tt.expectedParams), len(function.Parameters))
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expectedError {
+			t.Errorf("wrong error for %q. expected=%q, got=%q",
+				tt.input, tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
+func TestAssignExpression(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedTarget   string
+		expectedOperator string
+		expectedValue    string
+	}{
+		{"x = 5;", "x", "=", "5"},
+		{"x += y;", "x", "+=", "y"},
+		{"x -= 1 + 2;", "x", "-=", "(1 + 2)"},
+		{"x *= 2;", "x", "*=", "2"},
+		{"x /= 2;", "x", "/=", "2"},
+		{"arr[0] = 1;", "(arr[0])", "=", "1"},
+		{`h["a"] += 1;`, `(h["a"])`, "+=", "1"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+				1, len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T",
+				program.Statements[0])
+		}
+
+		exp, ok := stmt.Expression.(*ast.AssignExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.AssignExpression. got=%T",
+				stmt.Expression)
+		}
+
+		if exp.Target.String() != tt.expectedTarget {
+			t.Errorf("exp.Target is not %q. got=%q", tt.expectedTarget, exp.Target.String())
+		}
+
+		if exp.Operator != tt.expectedOperator {
+			t.Errorf("exp.Operator is not %q. got=%q", tt.expectedOperator, exp.Operator)
+		}
+
+		if exp.Value.String() != tt.expectedValue {
+			t.Errorf("exp.Value is not %q. got=%q", tt.expectedValue, exp.Value.String())
+		}
+	}
+}
+
+func TestPostfixExpression(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedLeft     string
+		expectedOperator string
+	}{
+		{"x++;", "x", "++"},
+		{"x--;", "x", "--"},
+		{"arr[0]++;", "(arr[0])", "++"},
+		{"f(x)?;", "f(x)", "?"},
+		{"(1 + 2)?;", "(1 + 2)", "?"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+				1, len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T",
+				program.Statements[0])
+		}
+
+		exp, ok := stmt.Expression.(*ast.PostfixExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.PostfixExpression. got=%T",
+				stmt.Expression)
+		}
+
+		if exp.Left.String() != tt.expectedLeft {
+			t.Errorf("exp.Left is not %q. got=%q", tt.expectedLeft, exp.Left.String())
+		}
+
+		if exp.Operator != tt.expectedOperator {
+			t.Errorf("exp.Operator is not %q. got=%q", tt.expectedOperator, exp.Operator)
+		}
+	}
+}
+
+func TestConditionalExpression(t *testing.T) {
+	input := `x < y ? x : y`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+			1, len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T",
+			program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.ConditionalExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.ConditionalExpression. got=%T",
+			stmt.Expression)
+	}
+
+	if !testInfixExpression(t, exp.Condition, "x", "<", "y") {
+		return
+	}
+
+	if !testIdentifier(t, exp.Consequence, "x") {
+		return
+	}
+
+	if !testIdentifier(t, exp.Alternative, "y") {
+		return
+	}
+}
+
+func TestConditionalExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"a ? b", "1:6: expected next token to be :, got EOF instead"},
+		{"a ? b; c", "1:6: expected next token to be :, got ; instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expectedError {
+			t.Errorf("wrong error for %q. expected=%q, got=%q",
+				tt.input, tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
+func TestLongPostfixChain(t *testing.T) {
+	// every ? here could start a conditional, so each of them has to look for a :
+	input := "let x = 1; " + strings.Repeat("x? - ", 20000) + "1"
+
+	start := time.Now()
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("parsing a chain of 20000 postfix ? took too long. got=%s", elapsed)
+	}
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+			2, len(program.Statements))
+	}
+}
+
+func TestAssignExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"5 = 1", "1:3: cannot assign to 5; only variables and elements of arrays or hashes can be assigned to"},
+		{"f() = 1", "1:5: cannot assign to f(); only variables and elements of arrays or hashes can be assigned to"},
+		{"a + b = 1", "1:7: cannot assign to (a + b); only variables and elements of arrays or hashes can be assigned to"},
+		{"5++", "1:2: cannot assign to 5; only variables and elements of arrays or hashes can be assigned to"},
+		{"f()--", "1:4: cannot assign to f(); only variables and elements of arrays or hashes can be assigned to"},
+		{"x-- y", "1:5: unexpected y after (x--); use ; to separate statements on the same line"},
+		{"a[0]++ 1", "1:8: unexpected 1 after ((a[0])++); use ; to separate statements on the same line"},
+		{"0xZ = 1", "1:3: invalid digit 'Z' in hexadecimal literal \"0xZ\""},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expectedError {
+			t.Errorf("wrong error for %q. expected=%q, got=%q",
+				tt.input, tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
+func TestFunctionLiteralParsing(t *testing.T) {
+	input := `fun(x, y) { x + y; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+			1, len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.ExpressionStatement. got=%T",
+			program.Statements[0])
+	}
+
+	function, ok := stmt.Expression.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.FunctionLiteral. got=%T",
+			stmt.Expression)
+	}
+
+	if len(function.Parameters) != 2 {
+		t.Fatalf("function literal parameters wrong. want 2, got=%d\n",
+			len(function.Parameters))
+	}
+
+	testLiteralExpression(t, function.Parameters[0], "x")
+	testLiteralExpression(t, function.Parameters[1], "y")
+
+	if len(function.Body.Statements) != 1 {
+		t.Fatalf("function.Body.Statements has not 1 statements. got=%d\n",
+			len(function.Body.Statements))
+	}
+
+	bodyStmt, ok := function.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("function body stmt is not ast.ExpressionStatement. got=%T",
+			function.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+}
+
+func TestFunctionStatementParsing(t *testing.T) {
+	input := `fun add(x, y) { x + y; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain %d statements. got=%d\n",
+			1, len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.FunctionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.FunctionStatement. got=%T",
+			program.Statements[0])
+	}
+
+	if !testIdentifier(t, stmt.Name, "add") {
+		return
+	}
+
+	if stmt.String() != "fun add(x, y)(x + y)" {
+		t.Errorf("stmt.String() wrong. got=%q", stmt.String())
+	}
+
+	function := stmt.Function
+	if function.Name != "add" {
+		t.Errorf("function.Name is not %q. got=%q", "add", function.Name)
+	}
+
+	if len(function.Parameters) != 2 {
+		t.Fatalf("function literal parameters wrong. want 2, got=%d\n",
+			len(function.Parameters))
+	}
+
+	testLiteralExpression(t, function.Parameters[0], "x")
+	testLiteralExpression(t, function.Parameters[1], "y")
+
+	if len(function.Body.Statements) != 1 {
+		t.Fatalf("function.Body.Statements has not 1 statements. got=%d\n",
+			len(function.Body.Statements))
+	}
+
+	bodyStmt, ok := function.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("function body stmt is not ast.ExpressionStatement. got=%T",
+			function.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+}
+
+func TestFunctionNames(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedName string
+	}{
+		{"let add = fun(x, y) { x + y };", "add"},
+		{"let add = fun(x, y) { x + y }(1, 2);", ""},
+		{"fun(x, y) { x + y };", ""},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		var value ast.Expression
+		switch stmt := program.Statements[0].(type) {
+		case *ast.LetStatement:
+			value = stmt.Value
+		case *ast.ExpressionStatement:
+			value = stmt.Expression
+		}
+
+		if call, ok := value.(*ast.CallExpression); ok {
+			value = call.Function
+		}
+
+		function, ok := value.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("value is not ast.FunctionLiteral. got=%T", value)
+		}
+
+		if function.Name != tt.expectedName {
+			t.Errorf("function.Name is not %q. got=%q", tt.expectedName, function.Name)
+		}
+	}
+}
+
+func TestFunctionParameterParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{input: "fun() {};", expectedParams: []string{}},
+		{input: "fun(x) {};", expectedParams: []string{"x"}},
+		{input: "fun(x, y, z) {};", expectedParams: []string{"x", "y", "z"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(function.Parameters) != len(tt.expectedParams) {
+			t.Errorf("length parameters wrong. want %d, got=%d\n",
+				len(tt.expectedParams), len(function.Parameters))
 		}
 
 		for i, ident := range tt.expectedParams {
@@ -671,6 +1496,162 @@ func TestCallExpressionParameterParsing(t *testing.T) {
 	}
 }
 
+func TestParsingArrayLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"[]", []string{}},
+		{"[1]", []string{"1"}},
+		{"[1, 2 * 2, 3 + 3]", []string{"1", "(2 * 2)", "(3 + 3)"}},
+		{`[foo, "bar", [1]]`, []string{"foo", `"bar"`, "[1]"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("stmt is not ast.ExpressionStatement. got=%T",
+				program.Statements[0])
+		}
+
+		array, ok := stmt.Expression.(*ast.ArrayLiteral)
+		if !ok {
+			t.Fatalf("exp not ast.ArrayLiteral. got=%T", stmt.Expression)
+		}
+
+		if len(array.Elements) != len(tt.expected) {
+			t.Fatalf("len(array.Elements) not %d. got=%d",
+				len(tt.expected), len(array.Elements))
+		}
+
+		for i, el := range tt.expected {
+			if array.Elements[i].String() != el {
+				t.Errorf("element %d wrong. want=%q, got=%q", i,
+					el, array.Elements[i].String())
+			}
+		}
+	}
+}
+
+func TestParsingIndexExpressions(t *testing.T) {
+	input := "myArray[1 + 1]"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("stmt is not ast.ExpressionStatement. got=%T",
+			program.Statements[0])
+	}
+
+	indexExp, ok := stmt.Expression.(*ast.IndexExpression)
+	if !ok {
+		t.Fatalf("exp not *ast.IndexExpression. got=%T", stmt.Expression)
+	}
+
+	if !testIdentifier(t, indexExp.Left, "myArray") {
+		return
+	}
+
+	if !testInfixExpression(t, indexExp.Index, 1, "+", 1) {
+		return
+	}
+}
+
+func TestParsingHashLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"{}", "{}"},
+		{`{"one": 1, "two": 2, "three": 3}`, `{"one": 1, "two": 2, "three": 3}`},
+		{`{true: 1, 2: "two", three: 3,}`, `{true: 1, 2: "two", three: 3}`},
+		{`{"one": 0 + 1, "two": 10 - 8, "three": 15 / 5}`, `{"one": (0 + 1), "two": (10 - 8), "three": (15 / 5)}`},
+		{`{"nested": {"a": [1]}}["nested"]`, `({"nested": {"a": [1]}}["nested"])`},
+		{`fun() { {"a": 1} }`, `fun(){"a": 1}`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statements. got=%d",
+				len(program.Statements))
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestParsingHashLiteralPairs(t *testing.T) {
+	input := `{"one": 1, two: 2, 3: true}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("exp is not ast.HashLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 3 {
+		t.Fatalf("hash.Pairs has wrong length. got=%d", len(hash.Pairs))
+	}
+
+	key, ok := hash.Pairs[0].Key.(*ast.StringLiteral)
+	if !ok || key.Value != "one" {
+		t.Errorf("first key is not \"one\". got=%s", hash.Pairs[0].Key)
+	}
+
+	testLiteralExpression(t, hash.Pairs[0].Value, 1)
+	testLiteralExpression(t, hash.Pairs[1].Key, "two")
+	testLiteralExpression(t, hash.Pairs[1].Value, 2)
+	testLiteralExpression(t, hash.Pairs[2].Key, 3)
+	testLiteralExpression(t, hash.Pairs[2].Value, true)
+}
+
+func TestParsingHashLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{`{"one" 1}`, "1:8: expected next token to be :, got INT instead"},
+		{`{"one": 1 "two": 2}`, "1:11: expected next token to be ,, got STRING instead; elements have to be separated with commas"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q, got none", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expectedError {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
 func testLetStatement(t *testing.T, s ast.Statement, name string) bool {
 	if s.TokenLiteral() != "let" {
 		t.Errorf("s.TokenLiteral not 'let'. got=%q", s.TokenLiteral())
@@ -816,3 +1797,242 @@ func checkParserErrors(t *testing.T, p *Parser) {
 	}
 	t.FailNow()
 }
+
+func TestParserErrorPositions(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{
+			"let x 5;",
+			"1:7: expected next token to be =, got INT instead",
+		},
+		{
+			"let x = 5;\nadd(1, 2",
+			"2:9: expected next token to be ), got EOF instead; is a closing ) missing?",
+		},
+		{
+			"let x = 5;\n  let y = );",
+			"2:11: no prefix parse function for ) found",
+		},
+		{
+			`let x = "oops`,
+			`1:9: illegal token "\"oops"`,
+		},
+		{
+			"1e400",
+			"1:1: could not parse \"1e400\" as float",
+		},
+		{
+			"99999999999999999999",
+			"1:1: integer literal \"99999999999999999999\" overflows int64",
+		},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("expected parser errors for %q, got none", tt.input)
+			continue
+		}
+
+		if errors[0].Error() != tt.expectedError {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expectedError, errors[0].Error())
+		}
+	}
+}
+
+func TestParserErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+	}{
+		{
+			`let x 5;
+let y = 10;
+let add = fun(a, b) {
+  a +* b;
+};
+let z = add(x, y;
+puts(z);`,
+			[]string{
+				"1:7: expected next token to be =, got INT instead",
+				"4:6: no prefix parse function for * found",
+				"6:17: expected next token to be ), got ; instead",
+			},
+		},
+		{
+			"if (x { 1 }; let y = 2; let 3 = y;",
+			[]string{
+				"1:7: expected next token to be ), got { instead",
+				"1:29: expected next token to be IDENT, got INT instead",
+			},
+		},
+		{
+			"let a = 1; }\nlet b = ;\nlet c = 3",
+			[]string{
+				"1:12: no prefix parse function for } found",
+				"2:9: no prefix parse function for ; found",
+			},
+		},
+		{
+			`let f = fun() { {"a": 1 "b": 2} };` + "\nlet g = 1 +;",
+			[]string{
+				"1:25: expected next token to be ,, got STRING instead; elements have to be separated with commas",
+				"2:12: no prefix parse function for ; found",
+			},
+		},
+		{
+			"let a = 0b12; let b = 0x;",
+			[]string{
+				"1:12: invalid digit '2' in binary literal \"0b12\"",
+				"1:23: hexadecimal literal \"0x\" has no digits",
+			},
+		},
+		{
+			"let x = 5 +\nlet y = 2;\ny +",
+			[]string{
+				"2:1: no prefix parse function for LET found",
+				"3:4: no prefix parse function for EOF found",
+			},
+		},
+		{
+			"let x = (1 + ;",
+			[]string{
+				"1:14: no prefix parse function for ; found",
+			},
+		},
+		{
+			"let x = 1 + ; fun f() { let = 1 } fun g( {}",
+			[]string{
+				"1:13: no prefix parse function for ; found",
+				"1:29: expected next token to be IDENT, got = instead",
+				"1:43: expected next token to be ), got } instead",
+			},
+		},
+		{
+			"let x = ) fun f() { 1 }",
+			[]string{
+				"1:9: no prefix parse function for ) found",
+			},
+		},
+		{
+			"let f = fun(x) { x + }; let b 2;",
+			[]string{
+				"1:22: no prefix parse function for } found",
+				"1:31: expected next token to be =, got INT instead",
+			},
+		},
+		{
+			`let h = {"a": ; let b 2; let c = ;`,
+			[]string{
+				"1:15: no prefix parse function for ; found",
+				"1:23: expected next token to be =, got INT instead",
+				"1:34: no prefix parse function for ; found",
+			},
+		},
+		{
+			`let h = {"a": , "b": 2}; let b 2;`,
+			[]string{
+				"1:15: no prefix parse function for , found",
+				"1:32: expected next token to be =, got INT instead",
+			},
+		},
+		{
+			"if (x) { x + } else { let = 1 }; let b 2;",
+			[]string{
+				"1:14: no prefix parse function for } found",
+				"1:27: expected next token to be IDENT, got = instead",
+				"1:40: expected next token to be =, got INT instead",
+			},
+		},
+		{
+			"while (true) { let = 1; break; }; let ok = 1",
+			[]string{
+				"1:20: expected next token to be IDENT, got = instead",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.expectedErrors) {
+			t.Errorf("wrong number of errors for %q. expected=%d, got=%d",
+				tt.input, len(tt.expectedErrors), len(errors))
+
+			for _, err := range errors {
+				t.Errorf("parser error: %q", err)
+			}
+
+			continue
+		}
+
+		for i, err := range errors {
+			if err.Error() != tt.expectedErrors[i] {
+				t.Errorf("wrong error. expected=%q, got=%q", tt.expectedErrors[i], err.Error())
+			}
+		}
+	}
+}
+
+func TestParserErrorRecoveryKeepsParsing(t *testing.T) {
+	input := `let x = ;
+let y = 2;
+fun() { let = 1; y; };
+y`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 2 {
+		t.Fatalf("wrong number of errors. expected=2, got=%d", len(p.Errors()))
+	}
+
+	expected := "let y = 2;fun()yy"
+	if program.String() != expected {
+		t.Errorf("expected=%q, got=%q", expected, program.String())
+	}
+}
+
+func TestParseErrorFields(t *testing.T) {
+	l := lexer.New("let x == 5;")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("wrong number of errors. expected=1, got=%d", len(errors))
+	}
+
+	err := errors[0]
+
+	if err.Pos.String() != "1:7" {
+		t.Errorf("err.Pos is not %q. got=%q", "1:7", err.Pos)
+	}
+
+	if len(err.Expected) != 1 || err.Expected[0] != token.ASSIGN {
+		t.Errorf("err.Expected is not [%s]. got=%v", token.ASSIGN, err.Expected)
+	}
+
+	if err.Found.Type != token.EQ || err.Found.Literal != "==" {
+		t.Errorf("err.Found is not %s. got=%+v", token.EQ, err.Found)
+	}
+
+	if err.Hint != "use = to bind a value, == compares two values" {
+		t.Errorf("wrong err.Hint. got=%q", err.Hint)
+	}
+
+	expected := "1:7: expected next token to be =, got == instead; use = to bind a value, == compares two values"
+	if err.Error() != expected {
+		t.Errorf("wrong err.Error(). expected=%q, got=%q", expected, err.Error())
+	}
+}
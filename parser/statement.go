@@ -13,6 +13,21 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.BREAK:
+		return p.parseBreakStatement()
+	case token.CONTINUE:
+		return p.parseContinueStatement()
+	case token.FUNCTION:
+		// anonymous functions are just expressions
+		if p.nextTokIs(token.IDENT) {
+			return p.parseFunctionStatement()
+		}
+
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -58,6 +73,11 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 
 	stmt.Value = p.parseExpression(LOWEST)
 
+	// name the function after the variable, for the stack traces
+	if fn, ok := stmt.Value.(*ast.FunctionLiteral); ok {
+		fn.Name = stmt.Name.Value
+	}
+
 	if p.nextTokIs(token.SEMICOLON) {
 		p.nextToken()
 	}
@@ -92,11 +112,30 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 		Statements: []ast.Statement{},
 	}
 
+	// remember how many `{` are open, so errors inside
+	// of the block can be recovered from within it
+	outerLevel := p.blockLevel
+	p.blockLevel = p.braces
+	defer func() { p.blockLevel = outerLevel }()
+
 	p.nextToken()
 
 	for !p.tokIs(token.RBRACE) && !p.tokIs(token.EOF) {
+		start := p.tok
 		stmt := p.parseStatement()
 
+		// skip over the rest of the broken statement
+		if p.panicking {
+			p.synchronize(start)
+
+			// the broken statement went past the `}` of the block
+			if p.braces < p.blockLevel {
+				break
+			}
+
+			continue
+		}
+
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -0,0 +1,12 @@
+package parser
+
+import "github.com/fr3fou/monkey/ast"
+
+// parseStringLiteral parses any string literal,
+// escape sequences are already decoded by the lexer
+func (p *Parser) parseStringLiteral() ast.Expression {
+	return &ast.StringLiteral{
+		Token: p.tok,
+		Value: p.tok.Literal,
+	}
+}
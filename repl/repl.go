@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/fr3fou/monkey/evaluator"
+	"github.com/fr3fou/monkey/object"
 	"github.com/fr3fou/monkey/parser"
 
 	"github.com/fr3fou/monkey/lexer"
@@ -18,6 +19,13 @@ const prompt = ">> "
 func Start(r io.Reader, w io.Writer) {
 	scanner := bufio.NewScanner(r)
 
+	// keep the same environment across lines,
+	// so bindings from previous lines are still available
+	env := object.NewEnvironment()
+
+	// print the output of puts alongside the results
+	evaluator.SetOutput(w)
+
 	fmt.Fprint(w, prompt)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -32,7 +40,7 @@ func Start(r io.Reader, w io.Writer) {
 			continue
 		}
 
-		evaluated := evaluator.Eval(program)
+		evaluated := evaluator.Eval(program, env)
 
 		if evaluated != nil {
 			io.WriteString(w, evaluated.Inspect())
@@ -43,8 +51,8 @@ func Start(r io.Reader, w io.Writer) {
 	}
 }
 
-func printParserErrors(out io.Writer, errors []string) {
-	for _, msg := range errors {
-		io.WriteString(out, "\t"+msg+"\n")
+func printParserErrors(out io.Writer, errors []*parser.ParseError) {
+	for _, err := range errors {
+		io.WriteString(out, "\t"+err.Error()+"\n")
 	}
 }
@@ -1,10 +1,37 @@
 package token
 
+import "fmt"
+
 // Token holds all the necessary fields that our
 // lexer is going to output
 type Token struct {
 	Type    Type
 	Literal string // TODO: This can be optimized by changing it to byte or int
+
+	// Pos is the position of the first character of the token
+	// and End is the position right after the last one
+	Pos Position
+	End Position
+}
+
+// Position points at a location in the source
+type Position struct {
+	Line   int // starting at 1
+	Column int // starting at 1
+	Offset int // byte offset, starting at 0
+}
+
+// IsValid reports whether the position has been set
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
 }
 
 // Type is used to determine the token variant
@@ -12,28 +39,47 @@ type Type string
 
 // keywords is a map that contains all the language defined keywords
 var keywords = map[string]Type{
-	"fun":    FUNCTION,
-	"let":    LET,
-	"true":   TRUE,
-	"false":  FALSE,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
+	"fun":      FUNCTION,
+	"let":      LET,
+	"true":     TRUE,
+	"false":    FALSE,
+	"if":       IF,
+	"else":     ELSE,
+	"return":   RETURN,
+	"while":    WHILE,
+	"for":      FOR,
+	"in":       IN,
+	"break":    BREAK,
+	"continue": CONTINUE,
 }
 
 // All possible token variants
 const (
 	ILLEGAL = "ILLEGAL"
 	EOF     = "EOF"
+	COMMENT = "COMMENT" // only emitted when the lexer is asked to
 
 	// Identifiers + literals
 
-	IDENT = "IDENT" // add, foobar, x, y, ...
-	INT   = "INT"   // 1343456
+	IDENT  = "IDENT"  // add, foobar, x, y, ...
+	INT    = "INT"    // 1343456
+	FLOAT  = "FLOAT"  // 3.14, 1e9
+	STRING = "STRING" // "foo bar"
 
 	// Operators
 
-	ASSIGN   = "="
+	ASSIGN = "="
+
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
+
+	INCREMENT = "++"
+	DECREMENT = "--"
+	QUESTION  = "?"
+	COALESCE  = "??"
+
 	EQ       = "=="
 	NEQ      = "!="
 	PLUS     = "+"
@@ -41,17 +87,32 @@ const (
 	BANG     = "!"
 	ASTERISK = "*"
 	SLASH    = "/"
+	PERCENT  = "%"
+	POWER    = "**"
 	LT       = "<"
 	GT       = ">"
+	LTE      = "<="
+	GTE      = ">="
+	AND      = "&&"
+	OR       = "||"
+	BIT_AND  = "&"
+	BIT_OR   = "|"
+	BIT_XOR  = "^"
+	BIT_NOT  = "~"
+	SHL      = "<<"
+	SHR      = ">>"
 
 	// Delimiters
 
 	COMMA     = ","
 	SEMICOLON = ";"
+	COLON     = ":"
 	LPAREN    = "("
 	RPAREN    = ")"
 	LBRACE    = "{"
 	RBRACE    = "}"
+	LBRACKET  = "["
+	RBRACKET  = "]"
 
 	// Keywords
 
@@ -62,6 +123,11 @@ const (
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	WHILE    = "WHILE"
+	FOR      = "FOR"
+	IN       = "IN"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
 )
 
 // LookupIdentifier checks if the given identifier is